@@ -0,0 +1,119 @@
+// Command busgen generates a domain-specific facade over a bus.Bus, with
+// EmitX and OnX methods for each event type of a package.
+//
+// Usage:
+//
+//	//go:generate go run github.com/mirkobrombin/go-signal/v2/cmd/busgen -types OrderCreated,OrderCancelled -trim Order
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+	"text/template"
+)
+
+type config struct {
+	Package string
+	Facade  string
+	Types   []string
+	Trim    string
+}
+
+type event struct {
+	Type string
+	Name string
+}
+
+var tmpl = template.Must(template.New("facade").Parse(`// Code generated by busgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+// {{.Facade}} is a typed facade over a bus.Bus for the {{.Package}} events.
+type {{.Facade}} struct {
+	b *bus.Bus
+}
+
+// New{{.Facade}} wraps b; a nil b uses the default bus.
+func New{{.Facade}}(b *bus.Bus) *{{.Facade}} {
+	return &{{.Facade}}{b: b}
+}
+
+// Bus returns the underlying bus.
+func (f *{{.Facade}}) Bus() *bus.Bus {
+	return f.b
+}
+{{range .Events}}
+func (f *{{$.Facade}}) Emit{{.Name}}(ctx context.Context, event {{.Type}}) error {
+	return bus.Emit(ctx, f.b, event)
+}
+
+func (f *{{$.Facade}}) Emit{{.Name}}Async(ctx context.Context, event {{.Type}}) {
+	bus.EmitAsync(ctx, f.b, event)
+}
+
+func (f *{{$.Facade}}) On{{.Name}}(fn func(ctx context.Context, event {{.Type}}) error, opts ...bus.SubscribeOption) *bus.Subscription {
+	return bus.Subscribe(f.b, fn, opts...)
+}
+{{end}}`))
+
+func generate(cfg config) ([]byte, error) {
+	if cfg.Package == "" {
+		return nil, fmt.Errorf("busgen: missing package name")
+	}
+	if len(cfg.Types) == 0 {
+		return nil, fmt.Errorf("busgen: no event types")
+	}
+	events := make([]event, 0, len(cfg.Types))
+	for _, typ := range cfg.Types {
+		typ = strings.TrimSpace(typ)
+		name := strings.TrimPrefix(strings.TrimPrefix(typ, "*"), cfg.Trim)
+		if name == "" {
+			return nil, fmt.Errorf("busgen: empty method name for %q", typ)
+		}
+		events = append(events, event{Type: typ, Name: name})
+	}
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, struct {
+		config
+		Events []event
+	}{cfg, events})
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+func main() {
+	var cfg config
+	var types, out string
+	flag.StringVar(&cfg.Package, "package", os.Getenv("GOPACKAGE"), "package of the generated file")
+	flag.StringVar(&cfg.Facade, "facade", "Bus", "name of the facade type")
+	flag.StringVar(&types, "types", "", "comma-separated event types")
+	flag.StringVar(&cfg.Trim, "trim", "", "prefix trimmed from event types in method names")
+	flag.StringVar(&out, "o", "bus_gen.go", "output file")
+	flag.Parse()
+
+	if types != "" {
+		cfg.Types = strings.Split(types, ",")
+	}
+	src, err := generate(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(out, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
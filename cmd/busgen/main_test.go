@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := generate(config{
+		Package: "orders",
+		Facade:  "Bus",
+		Types:   []string{"OrderCreated", "*OrderCancelled"},
+		Trim:    "Order",
+	})
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"package orders",
+		"func (f *Bus) EmitCreated(ctx context.Context, event OrderCreated) error",
+		"func (f *Bus) OnCancelled(fn func(ctx context.Context, event *OrderCancelled) error, opts ...bus.SubscribeOption) *bus.Subscription {",
+		"return bus.Subscribe(f.b, fn, opts...)",
+		"func (f *Bus) EmitCancelledAsync(ctx context.Context, event *OrderCancelled)",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("missing %q in:\n%s", want, src)
+		}
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	if _, err := generate(config{Types: []string{"A"}}); err == nil {
+		t.Error("expected error without package")
+	}
+	if _, err := generate(config{Package: "p"}); err == nil {
+		t.Error("expected error without types")
+	}
+	if _, err := generate(config{Package: "p", Types: []string{"Order"}, Trim: "Order"}); err == nil {
+		t.Error("expected error for empty method name")
+	}
+}
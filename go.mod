@@ -4,4 +4,8 @@ go 1.24.0
 
 toolchain go1.24.4
 
-require github.com/mirkobrombin/go-foundation v0.3.0
+require (
+	github.com/mirkobrombin/go-foundation v0.3.0
+	go.uber.org/goleak v1.3.0
+	golang.org/x/sync v0.19.0
+)
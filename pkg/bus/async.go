@@ -0,0 +1,35 @@
+package bus
+
+import "context"
+
+// AsyncContext selects the context handed to EmitAsync deliveries.
+type AsyncContext int
+
+const (
+	// AsyncInherit passes the emitter's context as is.
+	AsyncInherit AsyncContext = iota
+	// AsyncDetach passes a context that keeps the emitter's values but is
+	// never cancelled and has no deadline.
+	AsyncDetach
+	// AsyncKeepDeadline is like AsyncDetach but keeps the emitter's
+	// deadline, so deliveries get whatever time budget is left.
+	AsyncKeepDeadline
+)
+
+func WithAsyncContext(p AsyncContext) Option {
+	return func(b *Bus) { b.asyncContext = p }
+}
+
+func (b *Bus) detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	switch b.asyncContext {
+	case AsyncDetach:
+		return context.WithoutCancel(ctx), func() {}
+	case AsyncKeepDeadline:
+		dctx := context.WithoutCancel(ctx)
+		if deadline, ok := ctx.Deadline(); ok {
+			return context.WithDeadline(dctx, deadline)
+		}
+		return dctx, func() {}
+	}
+	return ctx, func() {}
+}
@@ -0,0 +1,53 @@
+package bus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestAsyncContext(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       bus.AsyncContext
+		wantCanceled bool
+		wantDeadline bool
+	}{
+		{"inherit", bus.AsyncInherit, true, true},
+		{"detach", bus.AsyncDetach, false, false},
+		{"keep deadline", bus.AsyncKeepDeadline, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := bus.New(bus.WithAsyncContext(tt.policy))
+			release := make(chan struct{})
+			type result struct {
+				canceled, deadline bool
+			}
+			got := make(chan result, 1)
+
+			bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+				<-release
+				_, hasDeadline := ctx.Deadline()
+				got <- result{canceled: ctx.Err() != nil, deadline: hasDeadline}
+				return nil
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			bus.EmitAsync(ctx, b, &Event{})
+			cancel()
+			close(release)
+
+			r := <-got
+			if r.canceled != tt.wantCanceled {
+				t.Fatalf("canceled: got %v, want %v", r.canceled, tt.wantCanceled)
+			}
+			if r.deadline != tt.wantDeadline {
+				t.Fatalf("deadline: got %v, want %v", r.deadline, tt.wantDeadline)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package bus
+
+import (
+	"iter"
+	"reflect"
+)
+
+// SubscriberInfo describes a handler as it would be invoked by a dispatch.
+type SubscriberInfo struct {
+	HandlerInfo
+	// Filtered is set when a matcher may skip deliveries to the handler.
+	Filtered bool
+	// Singleton is set when the handler only runs on the elected leader.
+	Singleton bool
+	// Interceptors is the number of bus interceptors wrapping the handler.
+	Interceptors int
+	// Middlewares is the number of bus middlewares wrapping the dispatch.
+	Middlewares int
+}
+
+// Subscribers returns the active handlers of T in the exact order a
+// dispatch would run them. The sequence reflects the subscriptions at the
+// time of the call.
+func Subscribers[T any](b *Bus) iter.Seq[SubscriberInfo] {
+	if b == nil {
+		b = defaultBus
+	}
+	subs, _ := b.subscribersOf(reflect.TypeFor[T](), "")
+	b.mu.RLock()
+	ics, mws := len(b.interceptors), len(b.middlewares)
+	b.mu.RUnlock()
+
+	return func(yield func(SubscriberInfo) bool) {
+		for _, s := range subs {
+			if s.cancelled.Load() {
+				continue
+			}
+			info := SubscriberInfo{
+				HandlerInfo:  s.info(),
+				Filtered:     s.matcher != nil,
+				Singleton:    s.elector != nil,
+				Interceptors: ics,
+				Middlewares:  mws,
+			}
+			if !yield(info) {
+				return
+			}
+		}
+	}
+}
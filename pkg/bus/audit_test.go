@@ -0,0 +1,46 @@
+package bus_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type PasswordChanged struct {
+	User string
+}
+
+type matchAll struct{}
+
+func (matchAll) Match(any) bool { return true }
+
+func TestSubscribers(t *testing.T) {
+	b := bus.New()
+	bus.Use(b, func(next bus.DispatchFunc) bus.DispatchFunc { return next })
+	bus.Subscribe(b, func(ctx context.Context, e PasswordChanged) error { return nil },
+		bus.WithName("notify"))
+	bus.Subscribe(b, func(ctx context.Context, e PasswordChanged) error { return nil },
+		bus.WithName("audit-log"), bus.PriorityHigh, bus.WithMatcher(matchAll{}))
+	revoked := bus.Subscribe(b, func(ctx context.Context, e PasswordChanged) error { return nil },
+		bus.WithName("revoked"))
+	revoked.Cancel()
+
+	var got []bus.SubscriberInfo
+	for info := range bus.Subscribers[PasswordChanged](b) {
+		got = append(got, info)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 subscribers, got %+v", got)
+	}
+	if got[0].Name != "audit-log" || !got[0].Filtered || got[0].Priority != bus.PriorityHigh {
+		t.Fatalf("Unexpected first subscriber: %+v", got[0])
+	}
+	if got[1].Name != "notify" || got[1].Filtered || got[1].Interceptors != 1 {
+		t.Fatalf("Unexpected second subscriber: %+v", got[1])
+	}
+
+	for range bus.Subscribers[InvoiceIssued](b) {
+		t.Fatal("Expected no subscribers")
+	}
+}
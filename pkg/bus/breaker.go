@@ -0,0 +1,86 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/mirkobrombin/go-foundation/pkg/resiliency"
+)
+
+// ErrCircuitOpen is returned for deliveries skipped by an open circuit
+// breaker.
+var ErrCircuitOpen = resiliency.ErrCircuitOpen
+
+// CircuitStateChanged is emitted on the bus when the circuit breaker of a
+// handler opens or closes.
+type CircuitStateChanged struct {
+	Handler HandlerInfo
+	Open    bool
+}
+
+// CircuitBreaker configures a circuit breaker for a subscription: after
+// Threshold consecutive failures the handler is skipped for Cooldown, then
+// probed again. Skipped deliveries fail with ErrCircuitOpen, or succeed
+// when Silent is set. A CircuitBreaker is passed to Subscribe as an option.
+type CircuitBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+	Silent    bool
+}
+
+func (c CircuitBreaker) applySubscribe(s *subscriber) {
+	br := &breaker{
+		cb:     resiliency.NewCircuitBreaker(c.Threshold, c.Cooldown),
+		silent: c.Silent,
+	}
+	br.cb.OnStateChange(func(from, to resiliency.State) {
+		if (from == resiliency.StateClosed) == (to == resiliency.StateClosed) {
+			return
+		}
+		br.mu.Lock()
+		defer br.mu.Unlock()
+		br.changes = append(br.changes, to != resiliency.StateClosed)
+	})
+	s.breaker = br
+}
+
+// WithCircuitBreaker is a CircuitBreaker reporting skipped deliveries
+// with ErrCircuitOpen.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) SubscribeOption {
+	return CircuitBreaker{Threshold: threshold, Cooldown: cooldown}
+}
+
+type breaker struct {
+	cb     *resiliency.CircuitBreaker
+	silent bool
+
+	// changes queues the transitions recorded by the breaker, true for
+	// opening, until an invocation dispatches them.
+	mu      sync.Mutex
+	changes []bool
+}
+
+func (br *breaker) run(ctx context.Context, b *Bus, s *subscriber, fn func() error) error {
+	err := br.cb.Execute(fn)
+	br.mu.Lock()
+	changes := br.changes
+	br.changes = nil
+	br.mu.Unlock()
+	for _, open := range changes {
+		_ = dispatch(ctx, b, newEnvelope(reflect.TypeFor[CircuitStateChanged](), CircuitStateChanged{
+			Handler: s.info(),
+			Open:    open,
+		}))
+	}
+	if errors.Is(err, ErrCircuitOpen) {
+		if br.silent {
+			return nil
+		}
+		return fmt.Errorf("bus: handler %s: %w", s.info().Name, err)
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := bus.New()
+	var changes []bool
+	bus.Subscribe(b, func(ctx context.Context, e bus.CircuitStateChanged) error {
+		changes = append(changes, e.Open)
+		return nil
+	})
+	healthy, calls := false, 0
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		calls++
+		if !healthy {
+			return errors.New("down")
+		}
+		return nil
+	}, bus.WithCircuitBreaker(2, 20*time.Millisecond))
+
+	ctx := context.Background()
+	_ = bus.Emit(ctx, b, OrderCreated{})
+	_ = bus.Emit(ctx, b, OrderCreated{})
+	if err := bus.Emit(ctx, b, OrderCreated{}); !errors.Is(err, bus.ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("Expected the open breaker to skip the handler, got %d calls", calls)
+	}
+
+	healthy = true
+	time.Sleep(30 * time.Millisecond)
+	if err := bus.Emit(ctx, b, OrderCreated{}); err != nil {
+		t.Fatalf("Expected the probe to succeed, got %v", err)
+	}
+	if len(changes) != 2 || !changes[0] || changes[1] {
+		t.Fatalf("Expected open then close events, got %v", changes)
+	}
+}
+
+func TestCircuitBreaker_Silent(t *testing.T) {
+	b := bus.New()
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		return errors.New("down")
+	}, bus.CircuitBreaker{Threshold: 1, Cooldown: time.Minute, Silent: true})
+
+	_ = bus.Emit(context.Background(), b, OrderCreated{})
+	if err := bus.Emit(context.Background(), b, OrderCreated{}); err != nil {
+		t.Fatalf("Expected skipped delivery to succeed silently, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_Concurrent(t *testing.T) {
+	b := bus.New()
+	var opened atomic.Int32
+	bus.Subscribe(b, func(ctx context.Context, e bus.CircuitStateChanged) error {
+		if e.Open {
+			opened.Add(1)
+		}
+		return nil
+	})
+	start := make(chan struct{})
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		<-start
+		return errors.New("down")
+	}, bus.WithCircuitBreaker(1, time.Minute))
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = bus.Emit(context.Background(), b, OrderCreated{})
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(start)
+	wg.Wait()
+
+	if n := opened.Load(); n != 1 {
+		t.Fatalf("Expected a single open event, got %d", n)
+	}
+}
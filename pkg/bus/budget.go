@@ -0,0 +1,95 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// ErrEmitBudgetExceeded is returned for emissions made by a handler over
+// its emit budget.
+var ErrEmitBudgetExceeded = errors.New("bus: handler emit budget exceeded")
+
+// EmitBudgetExceeded is emitted on the bus, once per invocation, when a
+// handler goes over its emit budget.
+type EmitBudgetExceeded struct {
+	Handler HandlerInfo
+	Type    reflect.Type
+	Limit   int
+}
+
+// EmitBudget limits how many events a single invocation of a handler may
+// emit, as a guardrail against one event fanning out into thousands.
+// Emissions over Limit fail with ErrEmitBudgetExceeded, or go through
+// when Lenient is set; either way an EmitBudgetExceeded event is emitted.
+// An EmitBudget is passed to Subscribe as an option.
+type EmitBudget struct {
+	Limit   int
+	Lenient bool
+}
+
+func (e EmitBudget) applySubscribe(s *subscriber) {
+	s.budget = &e
+}
+
+// WithEmitBudget is an EmitBudget failing emissions over limit.
+func WithEmitBudget(limit int) SubscribeOption {
+	return EmitBudget{Limit: limit}
+}
+
+// WithDefaultEmitBudget applies e to the handlers subscribed without an
+// EmitBudget of their own.
+func WithDefaultEmitBudget(e EmitBudget) Option {
+	return func(b *Bus) { b.emitBudget = &e }
+}
+
+type budgetKey struct{}
+
+// spending tracks the emissions of one handler invocation.
+type spending struct {
+	EmitBudget
+	handler *subscriber
+	emitted atomic.Int64
+}
+
+// withBudget scopes ctx to the emit budget of s. Emissions of nested
+// handlers without a budget are not charged to the handler emitting to
+// them.
+func (b *Bus) withBudget(ctx context.Context, s *subscriber) context.Context {
+	budget := s.budget
+	if budget == nil {
+		budget = b.emitBudget
+	}
+	if budget == nil || budget.Limit <= 0 {
+		if ctx.Value(budgetKey{}) != nil {
+			return context.WithValue(ctx, budgetKey{}, (*spending)(nil))
+		}
+		return ctx
+	}
+	return context.WithValue(ctx, budgetKey{}, &spending{EmitBudget: *budget, handler: s})
+}
+
+// spend charges an emission of key to the handler invocation running ctx.
+func (b *Bus) spend(ctx context.Context, key reflect.Type) error {
+	sp, _ := ctx.Value(budgetKey{}).(*spending)
+	if sp == nil {
+		return nil
+	}
+	n := sp.emitted.Add(1)
+	if n <= int64(sp.Limit) {
+		return nil
+	}
+	if n == int64(sp.Limit)+1 {
+		_ = dispatch(ctx, b, newEnvelope(reflect.TypeFor[EmitBudgetExceeded](), EmitBudgetExceeded{
+			Handler: sp.handler.info(),
+			Type:    key,
+			Limit:   sp.Limit,
+		}))
+	}
+	if sp.Lenient {
+		return nil
+	}
+	return fmt.Errorf("%w: %s emitted more than %d events", ErrEmitBudgetExceeded, sp.handler.info().Name, sp.Limit)
+}
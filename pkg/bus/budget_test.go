@@ -0,0 +1,79 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestEmitBudget(t *testing.T) {
+	b := bus.New()
+	ctx := context.Background()
+
+	var breaches []bus.EmitBudgetExceeded
+	bus.Subscribe(b, func(ctx context.Context, e bus.EmitBudgetExceeded) error {
+		breaches = append(breaches, e)
+		return nil
+	})
+	ticks := 0
+	bus.Subscribe(b, func(ctx context.Context, e Tick) error {
+		ticks++
+		// Emissions of nested handlers are not charged to the emitter.
+		return bus.Emit(ctx, b, InvoiceIssued{})
+	})
+	invoices := 0
+	bus.Subscribe(b, func(ctx context.Context, e InvoiceIssued) error {
+		invoices++
+		return nil
+	})
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		for i := range 5 {
+			if err := bus.Emit(ctx, b, Tick{Seq: i}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, bus.WithName("fanout"), bus.WithEmitBudget(3))
+
+	err := bus.Emit(ctx, b, OrderCreated{ID: 1})
+	if !errors.Is(err, bus.ErrEmitBudgetExceeded) {
+		t.Fatalf("Expected ErrEmitBudgetExceeded, got %v", err)
+	}
+	if ticks != 3 || invoices != 3 {
+		t.Fatalf("Expected 3 emissions within budget, got %d ticks and %d invoices", ticks, invoices)
+	}
+	if len(breaches) != 1 || breaches[0].Handler.Name != "fanout" || breaches[0].Limit != 3 {
+		t.Fatalf("Unexpected breach events: %+v", breaches)
+	}
+}
+
+func TestEmitBudget_Lenient(t *testing.T) {
+	b := bus.New(bus.WithDefaultEmitBudget(bus.EmitBudget{Limit: 1, Lenient: true}))
+	ctx := context.Background()
+
+	breaches := 0
+	bus.Subscribe(b, func(ctx context.Context, e bus.EmitBudgetExceeded) error {
+		breaches++
+		return nil
+	})
+	ticks := 0
+	bus.Subscribe(b, func(ctx context.Context, e Tick) error {
+		ticks++
+		return nil
+	})
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		for range 3 {
+			_ = bus.Emit(ctx, b, Tick{})
+		}
+		return nil
+	})
+
+	if err := bus.Emit(ctx, b, OrderCreated{}); err != nil {
+		t.Fatalf("Emit failed: %v", err)
+	}
+	if ticks != 3 || breaches != 1 {
+		t.Fatalf("Expected 3 ticks and 1 breach, got %d and %d", ticks, breaches)
+	}
+}
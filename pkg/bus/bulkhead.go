@@ -0,0 +1,97 @@
+package bus
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// WithBulkhead caps the number of concurrent dispatches of the event type T
+// at n, so a noisy type cannot hog the goroutines serving the others.
+// Dispatches over the limit wait for a slot or for their context to end;
+// on a worker pool they are set aside, freeing the worker, until a slot
+// is released. Emissions of T made by a handler of T reuse the slot of
+// its dispatch.
+func WithBulkhead[T any](n int) Option {
+	return func(b *Bus) {
+		if b.bulkheads == nil {
+			b.bulkheads = map[reflect.Type]*bulkhead{}
+		}
+		b.bulkheads[reflect.TypeFor[T]()] = &bulkhead{sem: make(chan struct{}, n)}
+	}
+}
+
+// bulkhead bounds the concurrent dispatches of an event type. Pool jobs
+// finding it full are parked and handed the slot of the next dispatch
+// releasing one.
+type bulkhead struct {
+	sem    chan struct{}
+	mu     sync.Mutex
+	parked []*job
+}
+
+// park takes a slot for j, reporting false, or sets j aside.
+func (h *bulkhead) park(j *job) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	select {
+	case h.sem <- struct{}{}:
+		return false
+	default:
+		h.parked = append(h.parked, j)
+		return true
+	}
+}
+
+// release hands the slot to the oldest parked job, if any, or frees it.
+func (h *bulkhead) release() {
+	h.mu.Lock()
+	if len(h.parked) == 0 {
+		<-h.sem
+		h.mu.Unlock()
+		return
+	}
+	j := h.parked[0]
+	h.parked = h.parked[1:]
+	h.mu.Unlock()
+	go j.run(true)
+}
+
+// heldKey marks the context of a dispatch holding bulkhead slots with the
+// event types of those slots.
+type heldKey struct{}
+
+func holding(ctx context.Context, key reflect.Type) context.Context {
+	held, _ := ctx.Value(heldKey{}).([]reflect.Type)
+	return context.WithValue(ctx, heldKey{}, append(held[:len(held):len(held)], key))
+}
+
+func (b *Bus) acquire(ctx context.Context, key reflect.Type) (context.Context, func(), error) {
+	h, ok := b.bulkheads[key]
+	if !ok {
+		return ctx, func() {}, nil
+	}
+	if held, _ := ctx.Value(heldKey{}).([]reflect.Type); slices.Contains(held, key) {
+		return ctx, func() {}, nil
+	}
+	select {
+	case h.sem <- struct{}{}:
+		return holding(ctx, key), h.release, nil
+	case <-ctx.Done():
+		return ctx, nil, ctx.Err()
+	}
+}
+
+// runJob runs j on the calling worker unless the bulkhead of its event
+// type is full, in which case j waits for a slot without the worker.
+func (b *Bus) runJob(j *job) {
+	h, ok := b.bulkheads[j.key]
+	if !ok {
+		j.run(false)
+		return
+	}
+	if !h.park(j) {
+		j.run(true)
+	}
+}
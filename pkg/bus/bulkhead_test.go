@@ -0,0 +1,118 @@
+package bus_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestBulkhead_LimitsConcurrency(t *testing.T) {
+	b := bus.New(bus.WithBulkhead[*Event](2))
+	var running, peak atomic.Int32
+
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = bus.Emit(context.Background(), b, &Event{})
+		}()
+	}
+	wg.Wait()
+
+	if p := peak.Load(); p > 2 {
+		t.Fatalf("Expected at most 2 concurrent dispatches, got %d", p)
+	}
+}
+
+func TestBulkhead_ContextExpires(t *testing.T) {
+	b := bus.New(bus.WithBulkhead[*Event](1))
+	release := make(chan struct{})
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		<-release
+		return nil
+	})
+
+	bus.EmitAsync(context.Background(), b, &Event{})
+	time.Sleep(5 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if err := bus.Emit(ctx, b, &Event{}); err != context.DeadlineExceeded {
+		t.Fatalf("Expected DeadlineExceeded, got %v", err)
+	}
+	close(release)
+}
+
+func TestBulkhead_Reentrant(t *testing.T) {
+	b := bus.New(bus.WithBulkhead[*Event](1))
+	var got []string
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		got = append(got, e.Greeting)
+		if e.Greeting == "outer" {
+			return bus.Emit(ctx, b, &Event{Greeting: "inner"})
+		}
+		return nil
+	})
+
+	done := make(chan error)
+	go func() { done <- bus.Emit(context.Background(), b, &Event{Greeting: "outer"}) }()
+	select {
+	case err := <-done:
+		if err != nil || len(got) != 2 {
+			t.Fatalf("Expected both deliveries, got %v, %v", got, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Nested emission deadlocked on its own bulkhead slot")
+	}
+}
+
+func TestBulkhead_WorkerPool(t *testing.T) {
+	b := bus.New(bus.WithWorkerPool(2, 10), bus.WithBulkhead[*Event](1))
+	release := make(chan struct{})
+	var events atomic.Int32
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		<-release
+		events.Add(1)
+		return nil
+	})
+	other := make(chan struct{})
+	bus.Subscribe(b, func(ctx context.Context, e InvoiceIssued) error {
+		close(other)
+		return nil
+	})
+
+	bus.EmitAsync(context.Background(), b, &Event{})
+	bus.EmitAsync(context.Background(), b, &Event{})
+	bus.EmitAsync(context.Background(), b, InvoiceIssued{})
+
+	select {
+	case <-other:
+	case <-time.After(time.Second):
+		t.Fatal("Bulkheaded events starved the worker pool")
+	}
+	close(release)
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if n := events.Load(); n != 2 {
+		t.Fatalf("Expected the parked event delivered, got %d deliveries", n)
+	}
+}
@@ -3,12 +3,16 @@ package bus
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
 	"reflect"
-	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/mirkobrombin/go-foundation/pkg/options"
 	"github.com/mirkobrombin/go-foundation/pkg/safemap"
+	"golang.org/x/sync/errgroup"
 )
 
 type Handler[T any] func(ctx context.Context, event T) error
@@ -31,19 +35,64 @@ const (
 type Middleware func(ctx context.Context, event any, next func(ctx context.Context, event any) error) error
 
 type Bus struct {
-	subscribers  *safemap.Map[reflect.Type, []subscriber]
-	strategy     DispatchStrategy
+	subscribers  *safemap.Map[reflect.Type, []*subscriber]
+	table        dispatchTable
+	strategy     Strategy
 	middlewares  []Middleware
+	interceptors []Interceptor
 	onAsyncError func(error)
-	wildcard     []subscriber
+	wildcard     []*subscriber
+	topics       *safemap.Map[topicKey, []*subscriber]
+	responders   *safemap.Map[reflect.Type, *subscriber]
+	commands     *safemap.Map[reflect.Type, *subscriber]
+	gatherers    *safemap.Map[gatherKey, []*subscriber]
+	patterns     topicTrie
+	producers    *safemap.Map[reflect.Type, string]
+	deprecated   *safemap.Map[reflect.Type, string]
+	enrichers    *safemap.Map[reflect.Type, []enricher]
+	sticky       *safemap.Map[reflect.Type, retained]
+	encoders     *safemap.Map[reflect.Type, func(any) ([]byte, error)]
+	stickyTypes  map[reflect.Type]bool
+	replays      map[reflect.Type]*replayRing
+	strict       bool
+	logger       *slog.Logger
+	errs         chan DispatchError
+	errsClosed   bool
+	group        *errgroup.Group
+	inflight     gate
+	asyncContext AsyncContext
+	seq          atomic.Uint64
+	requireStart bool
+	started      atomic.Bool
+	startMu      sync.Mutex
+	pending      []func() error
+	pendingLimit int
+	maxEventSize sizeLimit
+	eventSizes   map[reflect.Type]sizeLimit
+	oversizeWarn bool
+	bulkheads    map[reflect.Type]*bulkhead
+	quotas       *quotas
+	tenants      *tenants
+	latencies    *latencies
+	recovery     RecoveryPolicy
+	finalizers   []Finalizer
+	components   []*component
+	emitBudget   *EmitBudget
+	deadLetter   DeadLetter
+	tracker      tracker
+	done         chan struct{}
+	closeOnce    sync.Once
+	background   sync.WaitGroup
+	watchdogAge  time.Duration
+	watchdogTick time.Duration
+	tracing      bool
+	poly         *polymorphism
+	workers      int
+	queue        chan *job
+	overflow     OverflowPolicy
 	mu           sync.RWMutex
 }
 
-type subscriber struct {
-	handler  any
-	priority Priority
-}
-
 var defaultBus = New()
 
 func Default() *Bus {
@@ -54,14 +103,35 @@ type Option = options.Option[Bus]
 
 func New(opts ...Option) *Bus {
 	b := &Bus{
-		subscribers: safemap.New[reflect.Type, []subscriber](),
+		subscribers: safemap.New[reflect.Type, []*subscriber](),
+		topics:      safemap.New[topicKey, []*subscriber](),
+		responders:  safemap.New[reflect.Type, *subscriber](),
+		commands:    safemap.New[reflect.Type, *subscriber](),
+		gatherers:   safemap.New[gatherKey, []*subscriber](),
+		producers:   safemap.New[reflect.Type, string](),
+		deprecated:  safemap.New[reflect.Type, string](),
+		enrichers:   safemap.New[reflect.Type, []enricher](),
+		sticky:      safemap.New[reflect.Type, retained](),
+		encoders:    safemap.New[reflect.Type, func(any) ([]byte, error)](),
 		strategy:    StopOnFirstError,
+		logger:      slog.Default(),
+		done:        make(chan struct{}),
 	}
 	options.Apply(b, opts...)
+	if b.watchdogTick > 0 {
+		b.background.Add(1)
+		go b.watch()
+	}
+	b.startWorkers()
 	return b
 }
 
-func WithStrategy(s DispatchStrategy) Option {
+// WithStrategy sets how dispatches run their handlers. A nil strategy
+// means StopOnFirstError.
+func WithStrategy(s Strategy) Option {
+	if s == nil {
+		s = StopOnFirstError
+	}
 	return func(b *Bus) { b.strategy = s }
 }
 
@@ -69,108 +139,326 @@ func WithOnAsyncError(fn func(error)) Option {
 	return func(b *Bus) { b.onAsyncError = fn }
 }
 
+// WithGroup launches EmitAsync work within g instead of detached
+// goroutines, so the caller can join it with g.Wait. Dispatch errors are
+// returned to the group as well as passed to the async error callback.
+func WithGroup(g *errgroup.Group) Option {
+	return func(b *Bus) { b.group = g }
+}
+
+// WithStrict enables validation of the event catalog: emitting a type
+// without a declared producer is reported through the logger, and emitting
+// or subscribing to a deprecated type fails with ErrDeprecated.
+func WithStrict() Option {
+	return func(b *Bus) { b.strict = true }
+}
+
+// WithExplicitStart separates wiring from running: until Bus.Start is
+// called, Emit fails with ErrNotStarted.
+func WithExplicitStart() Option {
+	return func(b *Bus) { b.requireStart = true }
+}
+
+// WithStartupBuffer is like WithExplicitStart but holds up to limit events
+// emitted before Bus.Start and delivers them, in order, once the bus starts.
+// Emissions beyond the limit fail with ErrStartupBufferFull.
+func WithStartupBuffer(limit int) Option {
+	return func(b *Bus) {
+		b.requireStart = true
+		b.pendingLimit = limit
+	}
+}
+
+func WithLogger(l *slog.Logger) Option {
+	return func(b *Bus) { b.logger = l }
+}
+
 func (b *Bus) Use(mw Middleware) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	b.middlewares = append(b.middlewares, mw)
 }
 
-func Subscribe[T any](b *Bus, fn Handler[T], priority ...Priority) {
+func Subscribe[T any](b *Bus, fn Handler[T], opts ...SubscribeOption) *Subscription {
 	if b == nil {
 		b = defaultBus
 	}
-	p := PriorityNormal
-	if len(priority) > 0 {
-		p = priority[0]
+	return b.subscribe(newSubscriber(fn, opts))
+}
+
+func (b *Bus) subscribe(sub *subscriber) *Subscription {
+	sub.seq = b.seq.Add(1)
+	key := sub.key
+	if b.inflight.isClosed() {
+		sub.cancelled.Store(true)
+		return &Subscription{bus: b, key: key, sub: sub, err: ErrClosed}
 	}
-	key := reflect.TypeFor[T]()
-	b.subscribers.Compute(key, func(subs []subscriber, exists bool) []subscriber {
-		newSubs := append(subs, subscriber{handler: fn, priority: p})
-		sort.SliceStable(newSubs, func(i, j int) bool {
-			return newSubs[i].priority > newSubs[j].priority
-		})
-		return newSubs
-	})
+	if err := b.checkDependencies(sub); err != nil {
+		sub.cancelled.Store(true)
+		return &Subscription{bus: b, key: key, sub: sub, err: err}
+	}
+	if err := b.checkDeprecated(key); err != nil {
+		sub.cancelled.Store(true)
+		return &Subscription{bus: b, key: key, sub: sub, err: err}
+	}
+	sub.backlog = &backlog{}
+	b.subscribers.Compute(key, insert(sub))
+	b.table.invalidate()
+	b.poly.invalidate(key)
+	b.catchUp(sub)
+	return &Subscription{bus: b, key: key, sub: sub}
 }
 
-func SubscribeWildcard(b *Bus, fn func(ctx context.Context, event any) error) {
+func Emit[T any](ctx context.Context, b *Bus, event T) error {
 	if b == nil {
 		b = defaultBus
 	}
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	b.wildcard = append(b.wildcard, subscriber{handler: fn})
+	return b.send(ctx, newEnvelope(reflect.TypeFor[T](), event))
 }
 
-func Emit[T any](ctx context.Context, b *Bus, event T) error {
+// EmitAny emits event keyed by its dynamic type, for callers that only
+// know the event type at runtime. Subscribers of interface types are not
+// reached, as with Emit of the concrete type.
+func EmitAny(ctx context.Context, b *Bus, event any) error {
 	if b == nil {
 		b = defaultBus
 	}
-	key := reflect.TypeFor[T]()
-	subs, ok := b.subscribers.Get(key)
+	if event == nil {
+		return ErrNilEvent
+	}
+	return b.send(ctx, newEnvelope(reflect.TypeOf(event), event))
+}
+
+// send emits env unless the bus is closed, holding Close until the
+// dispatch is over.
+func (b *Bus) send(ctx context.Context, env envelope) error {
+	if !b.inflight.enter() {
+		return ErrClosed
+	}
+	defer b.inflight.leave()
+	return emit(ctx, b, env)
+}
+
+func emit(ctx context.Context, b *Bus, env envelope) error {
+	if err := b.spend(ctx, env.key); err != nil {
+		return err
+	}
+	if err := b.checkQuota(ctx, env); err != nil {
+		return err
+	}
+	env.tenant = b.tenantOf(ctx, env.event)
+	if err := b.checkTenantQuota(ctx, env); err != nil {
+		return err
+	}
+	if b.requireStart && !b.started.Load() {
+		queued, err := b.enqueue(func() error {
+			return dispatch(ctx, b, env)
+		})
+		if queued || err != nil {
+			return err
+		}
+	}
+	return dispatch(ctx, b, env)
+}
+
+func dispatch(ctx context.Context, b *Bus, env envelope) error {
+	key, event := env.key, env.event
+	env.cause = provenance(ctx)
+	if env.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, env.timeout)
+		defer cancel()
+	}
+	if b.strict && !b.producers.Has(key) {
+		b.logger.Warn("bus: event emitted without a declared producer", "type", key.String())
+	}
+	if reason, ok := b.deprecated.Get(key); ok {
+		if b.strict {
+			return fmt.Errorf("%w: %s: %s", ErrDeprecated, key, reason)
+		}
+		b.logger.Warn("bus: deprecated event type emitted", "type", key.String(), "reason", reason)
+	}
+	event = b.enrich(ctx, key, event)
+	if err := b.checkSize(key, event); err != nil {
+		return err
+	}
+	b.retain(env, event)
+	ctx, release, err := b.acquire(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer release()
+	ctx, releaseTenant, err := b.acquireTenant(ctx, env.tenant)
+	if err != nil {
+		return err
+	}
+	defer releaseTenant()
+	ctx, end := b.traceDispatch(ctx, key)
+	defer end()
+	subs, ok := b.subscribersOf(key, env.topic)
 
 	b.mu.RLock()
 	mws := b.middlewares
 	b.mu.RUnlock()
 
-	emit := func(ctx context.Context, evt any) error {
-		if !ok {
-			return nil
+	handlers := func(ctx context.Context, evt any) error {
+		if b.latencies != nil {
+			firstStart := time.Since(env.emitted)
+			defer func() {
+				b.latencies.record(key, firstStart, time.Since(env.emitted))
+			}()
 		}
-		var errs []error
-		for _, sub := range subs {
-			if fn, ok := sub.handler.(Handler[T]); ok {
-				if err := fn(ctx, evt.(T)); err != nil {
-					if b.strategy == StopOnFirstError {
-						return err
+		var failed failures
+		targets := make([]Target, len(subs))
+		for i, sub := range subs {
+			targets[i] = Target{
+				HandlerInfo: sub.info(),
+				Deliver: func(ctx context.Context, evt any) error {
+					err := b.deliver(ctx, sub, evt, env)
+					if err == nil {
+						return nil
 					}
-					errs = append(errs, err)
-				}
+					herr := &HandlerError{Name: sub.info().Name, Type: key, Err: err}
+					failed.add(failure{ctx: ctx, sub: sub, err: herr, dispatch: DispatchError{
+						Handler:  sub.info(),
+						Type:     key,
+						Event:    evt,
+						Priority: sub.priority,
+						Async:    env.async,
+						Err:      err,
+					}})
+					return herr
+				},
 			}
 		}
-		if len(errs) > 0 {
-			return errors.Join(errs...)
+		err := b.execute(ctx, env, targets, evt)
+		b.reportFailures(err, &failed)
+		return err
+	}
+
+	emit := func(ctx context.Context, evt any) error {
+		var err error
+		if ok {
+			err = handlers(ctx, evt)
+		} else {
+			// The strategy still decides, e.g. a quorum no handler meets.
+			err = b.execute(ctx, env, nil, evt)
+		}
+		if werr := b.deliverAll(ctx, evt, env); werr != nil {
+			return errors.Join(err, werr)
 		}
-		return nil
+		return err
 	}
 
 	if len(mws) > 0 {
-		chain := applyMiddleware(emit, mws)
-		return chain(ctx, event)
+		return applyMiddleware(emit, mws)(ctx, event)
 	}
+	return emit(ctx, event)
+}
 
-	if err := emit(ctx, event); err != nil {
+func EmitAsync[T any](ctx context.Context, b *Bus, event T) {
+	if b == nil {
+		b = defaultBus
+	}
+	emitAsync(ctx, b, newEnvelope(reflect.TypeFor[T](), event))
+}
+
+func emitAsync(ctx context.Context, b *Bus, env envelope) *Future {
+	env.async = true
+	f := newFuture()
+	if !b.inflight.enter() {
+		b.asyncError(ErrClosed)
+		f.resolve(ErrClosed)
+		return f
+	}
+	if b.quotas != nil {
+		env.producer = b.producerOf(ctx, env.key)
+	}
+	ctx, cancel := b.detach(ctx)
+	// The dispatch emitting event may be over before it runs.
+	for _, key := range []any{admittedKey{}, heldKey{}, onceKey{}} {
+		if ctx.Value(key) != nil {
+			ctx = context.WithValue(ctx, key, nil)
+		}
+	}
+	id := b.tracker.add(env.key)
+	run := func() error {
+		defer cancel()
+		defer b.tracker.remove(id)
+		err := emit(ctx, b, env)
+		if err != nil {
+			b.asyncError(err)
+		}
+		f.resolve(err)
 		return err
 	}
+	if b.queue != nil && b.submit(&job{
+		key: env.key,
+		run: func(held bool) {
+			defer b.inflight.leave()
+			if held {
+				ctx = holding(ctx, env.key)
+				defer b.bulkheads[env.key].release()
+			}
+			_ = run()
+		},
+		reject: func(err error, report bool) {
+			defer b.inflight.leave()
+			cancel()
+			b.tracker.remove(id)
+			if report {
+				b.asyncError(err)
+			}
+			f.resolve(err)
+		},
+	}) {
+		return f
+	}
+	if b.group != nil {
+		b.group.Go(func() error {
+			defer b.inflight.leave()
+			return run()
+		})
+		return f
+	}
+	go func() {
+		defer b.inflight.leave()
+		_ = run()
+	}()
+	return f
+}
 
+func (b *Bus) asyncError(err error) {
 	b.mu.RLock()
-	wildcards := b.wildcard
+	fn := b.onAsyncError
 	b.mu.RUnlock()
-	for _, w := range wildcards {
-		if fn, ok := w.handler.(func(ctx context.Context, event any) error); ok {
-			if err := fn(ctx, event); err != nil {
-				return err
-			}
-		}
+	if fn != nil {
+		fn(err)
 	}
-
-	return nil
 }
 
-func EmitAsync[T any](ctx context.Context, b *Bus, event T) {
-	if b == nil {
-		b = defaultBus
-	}
+// Close stops the managed components, then stops accepting emissions and
+// subscriptions, which then fail with ErrClosed, and waits for the
+// dispatches already accepted to finish, or for ctx to expire. It then
+// stops the background goroutines of the bus and the handlers implementing
+// Lifecycle in reverse start order.
+func (b *Bus) Close(ctx context.Context) error {
+	stopErr := b.stopComponents(ctx)
+	idle := b.inflight.shut()
+	done := make(chan struct{})
 	go func() {
-		if err := Emit(ctx, b, event); err != nil {
-			b.mu.RLock()
-			fn := b.onAsyncError
-			b.mu.RUnlock()
-			if fn != nil {
-				fn(err)
-			}
-		}
+		<-idle
+		b.closeOnce.Do(func() { close(b.done) })
+		b.background.Wait()
+		b.closeErrors()
+		close(done)
 	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return errors.Join(stopErr, ctx.Err())
+	}
+	return errors.Join(stopErr, b.stopLifecycles(ctx))
 }
 
 func applyMiddleware(handler func(ctx context.Context, evt any) error, middlewares []Middleware) func(ctx context.Context, evt any) error {
@@ -183,4 +471,3 @@ func applyMiddleware(handler func(ctx context.Context, evt any) error, middlewar
 	}
 	return handler
 }
-
@@ -3,11 +3,14 @@ package bus_test
 import (
 	"context"
 	"errors"
+	"reflect"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+	"golang.org/x/sync/errgroup"
 )
 
 type Event struct {
@@ -108,7 +111,8 @@ func TestBus_StopOnFirstError(t *testing.T) {
 	}, bus.PriorityLow)
 
 	err := bus.Emit(context.Background(), b, &Event{})
-	if err == nil || err.Error() != "error 1" {
+	var he *bus.HandlerError
+	if !errors.As(err, &he) || he.Err.Error() != "error 1" {
 		t.Fatalf("Expected 'error 1', got %v", err)
 	}
 }
@@ -139,6 +143,33 @@ func TestBus_BestEffort(t *testing.T) {
 	}
 }
 
+func TestBus_HandlerNames(t *testing.T) {
+	b := bus.New(bus.WithStrategy(bus.BestEffort))
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		return errors.New("error 1")
+	}, bus.PriorityHigh, bus.WithName("billing.invoicer"))
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		return errors.New("error 2")
+	}, bus.PriorityLow)
+
+	err := bus.Emit(context.Background(), b, &Event{})
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+
+	var names []string
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var he *bus.HandlerError
+		if !errors.As(err, &he) || he.Type != reflect.TypeFor[*Event]() {
+			t.Fatalf("Expected attributed error, got %v", err)
+		}
+		names = append(names, he.Name)
+	}
+	if len(names) != 2 || names[0] != "billing.invoicer" || names[1] == "" {
+		t.Fatalf("Unexpected handler names: %v", names)
+	}
+}
+
 func TestBus_Async(t *testing.T) {
 	b := bus.New()
 	wg := sync.WaitGroup{}
@@ -163,3 +194,83 @@ func TestBus_Async(t *testing.T) {
 		t.Fatal("Timeout waiting for async")
 	}
 }
+
+func TestBus_WithGroup(t *testing.T) {
+	var g errgroup.Group
+	b := bus.New(bus.WithGroup(&g))
+	boom := errors.New("boom")
+	calls := 0
+
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		calls++
+		return boom
+	})
+
+	bus.EmitAsync(context.Background(), b, &Event{})
+
+	if err := g.Wait(); !errors.Is(err, boom) {
+		t.Fatalf("Expected boom from group, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestBus_CloseWaitsForAsync(t *testing.T) {
+	b := bus.New()
+	done := false
+
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		time.Sleep(20 * time.Millisecond)
+		done = true
+		return nil
+	})
+
+	bus.EmitAsync(context.Background(), b, &Event{})
+
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if !done {
+		t.Fatal("Close returned before async dispatch finished")
+	}
+}
+
+func TestBus_CloseDrainsAndRejects(t *testing.T) {
+	b := bus.New()
+	started, release := make(chan struct{}), make(chan struct{})
+	var done atomic.Bool
+
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		close(started)
+		<-release
+		done.Store(true)
+		return nil
+	})
+
+	go func() { _ = bus.Emit(context.Background(), b, &Event{}) }()
+	<-started
+
+	closed := make(chan error)
+	go func() { closed <- b.Close(context.Background()) }()
+	time.Sleep(10 * time.Millisecond)
+
+	if err := bus.Emit(context.Background(), b, &Event{}); !errors.Is(err, bus.ErrClosed) {
+		t.Fatalf("Expected ErrClosed, got %v", err)
+	}
+	if err := bus.EmitFuture(context.Background(), b, &Event{}).Wait(); !errors.Is(err, bus.ErrClosed) {
+		t.Fatalf("Expected ErrClosed for async emit, got %v", err)
+	}
+	sub := bus.Subscribe(b, func(ctx context.Context, e *Event) error { return nil })
+	if !errors.Is(sub.Err(), bus.ErrClosed) || sub.Active() {
+		t.Fatalf("Expected rejected subscription, got %v", sub.Err())
+	}
+
+	close(release)
+	if err := <-closed; err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if !done.Load() {
+		t.Fatal("Close returned before sync dispatch finished")
+	}
+}
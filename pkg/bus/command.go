@@ -0,0 +1,47 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrNoCommandHandler is returned by Dispatch for commands without a
+	// handler.
+	ErrNoCommandHandler = errors.New("bus: no command handler")
+	// ErrCommandHandlerExists is reported by Subscription.Err when a
+	// command type already has a handler.
+	ErrCommandHandlerExists = errors.New("bus: command handler already registered")
+)
+
+// RegisterCommand registers fn as the handler of commands of type T.
+// Unlike events, a command has exactly one handler: further registrations
+// are reported by Subscription.Err. Subscribe options apply to the handler.
+func RegisterCommand[T any](b *Bus, fn Handler[T], opts ...SubscribeOption) *Subscription {
+	if b == nil {
+		b = defaultBus
+	}
+	return b.registerSingle(b.commands, newSubscriber(fn, opts), ErrCommandHandlerExists)
+}
+
+// Dispatch runs the handler of the command cmd and returns its error, or
+// ErrNoCommandHandler when there is none. Commands reach neither the
+// subscribers of T nor the bus middlewares, but interceptors apply.
+func Dispatch[T any](ctx context.Context, b *Bus, cmd T) error {
+	if b == nil {
+		b = defaultBus
+	}
+	if !b.inflight.enter() {
+		return ErrClosed
+	}
+	defer b.inflight.leave()
+
+	key := reflect.TypeFor[T]()
+	sub, _ := b.commands.Get(key)
+	if sub == nil {
+		return fmt.Errorf("%w: %s", ErrNoCommandHandler, key)
+	}
+	return b.deliver(ctx, sub, cmd, newEnvelope(key, cmd))
+}
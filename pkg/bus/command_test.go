@@ -0,0 +1,53 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type ShipOrder struct {
+	ID int
+}
+
+func TestCommand(t *testing.T) {
+	b := bus.New()
+	ctx := context.Background()
+
+	if err := bus.Dispatch(ctx, b, ShipOrder{ID: 1}); !errors.Is(err, bus.ErrNoCommandHandler) {
+		t.Fatalf("Expected ErrNoCommandHandler, got %v", err)
+	}
+
+	var shipped []int
+	sub := bus.RegisterCommand(b, func(ctx context.Context, c ShipOrder) error {
+		shipped = append(shipped, c.ID)
+		return nil
+	})
+	dup := bus.RegisterCommand(b, func(ctx context.Context, c ShipOrder) error { return nil })
+	if sub.Err() != nil || !errors.Is(dup.Err(), bus.ErrCommandHandlerExists) {
+		t.Fatalf("Unexpected registration errors: %v, %v", sub.Err(), dup.Err())
+	}
+
+	eventHandler := false
+	bus.Subscribe(b, func(ctx context.Context, c ShipOrder) error {
+		eventHandler = true
+		return nil
+	})
+
+	if err := bus.Dispatch(ctx, b, ShipOrder{ID: 2}); err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if len(shipped) != 1 || shipped[0] != 2 || eventHandler {
+		t.Fatalf("Unexpected handling: shipped=%v eventHandler=%v", shipped, eventHandler)
+	}
+
+	sub.Cancel()
+	if err := bus.Dispatch(ctx, b, ShipOrder{ID: 3}); !errors.Is(err, bus.ErrNoCommandHandler) {
+		t.Fatalf("Expected ErrNoCommandHandler after Cancel, got %v", err)
+	}
+	if err := bus.RegisterCommand(b, func(ctx context.Context, c ShipOrder) error { return nil }).Err(); err != nil {
+		t.Fatalf("Expected re-registration after Cancel, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mirkobrombin/go-foundation/pkg/options"
+)
+
+// Component is a resource owned by the bus, such as a bridge, a ticker, a
+// watcher or a pool, started and stopped along with it.
+type Component interface {
+	Lifecycle
+}
+
+type component struct {
+	Component
+	timeout time.Duration
+	started bool
+}
+
+// ComponentOption configures a managed component.
+type ComponentOption = options.Option[component]
+
+// WithStopTimeout bounds the time the component may take to stop. A
+// component still stopping when it expires is reported and left behind.
+func WithStopTimeout(d time.Duration) ComponentOption {
+	return func(c *component) { c.timeout = d }
+}
+
+// Manage hands c over to the bus. It is started right away, or by
+// Bus.Start on buses created with WithExplicitStart or WithStartupBuffer
+// that have not started yet. Bus.Close stops managed components in reverse
+// order before draining the bus, so sources stop feeding it first.
+func (b *Bus) Manage(c Component, opts ...ComponentOption) error {
+	if b.inflight.isClosed() {
+		return ErrClosed
+	}
+	mc := &component{Component: c}
+	options.Apply(mc, opts...)
+	b.mu.Lock()
+	b.components = append(b.components, mc)
+	deferred := b.requireStart && !b.started.Load()
+	b.mu.Unlock()
+	if deferred {
+		return nil
+	}
+	return b.startComponent(context.Background(), mc)
+}
+
+func (b *Bus) startComponent(ctx context.Context, c *component) error {
+	if err := c.Start(ctx); err != nil {
+		return fmt.Errorf("bus: start of %T failed: %w", c.Component, err)
+	}
+	b.mu.Lock()
+	c.started = true
+	b.mu.Unlock()
+	return nil
+}
+
+// startComponents starts the managed components not started yet, in
+// order, stopping at the first failure.
+func (b *Bus) startComponents(ctx context.Context) error {
+	b.mu.RLock()
+	var pending []*component
+	for _, c := range b.components {
+		if !c.started {
+			pending = append(pending, c)
+		}
+	}
+	b.mu.RUnlock()
+	for _, c := range pending {
+		if err := b.startComponent(ctx, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stopComponents stops the started components in reverse order, each
+// within its own timeout, and joins their errors.
+func (b *Bus) stopComponents(ctx context.Context) error {
+	b.mu.Lock()
+	comps := b.components
+	b.components = nil
+	b.mu.Unlock()
+	var errs []error
+	for i := len(comps) - 1; i >= 0; i-- {
+		c := comps[i]
+		if !c.started {
+			continue
+		}
+		if err := c.stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("bus: stop of %T failed: %w", c.Component, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *component) stop(ctx context.Context) error {
+	if c.timeout <= 0 {
+		return c.Stop(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- c.Stop(ctx) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
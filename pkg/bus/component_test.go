@@ -0,0 +1,90 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type journal struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+func (j *journal) add(s string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries = append(j.entries, s)
+}
+
+func (j *journal) get() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return slices.Clone(j.entries)
+}
+
+type source struct {
+	name  string
+	log   *journal
+	hang  chan struct{}
+	fails error
+}
+
+func (s *source) Start(ctx context.Context) error {
+	s.log.add("start " + s.name)
+	return nil
+}
+
+func (s *source) Stop(ctx context.Context) error {
+	s.log.add("stop " + s.name)
+	if s.hang != nil {
+		<-s.hang
+	}
+	return s.fails
+}
+
+func TestManage(t *testing.T) {
+	b := bus.New(bus.WithExplicitStart())
+	log := &journal{}
+	boom := errors.New("boom")
+	hang := make(chan struct{})
+	defer close(hang)
+
+	_ = b.Manage(&source{name: "bridge", log: log})
+	_ = b.Manage(&source{name: "watcher", log: log, hang: hang}, bus.WithStopTimeout(10*time.Millisecond))
+	_ = b.Manage(&source{name: "ticker", log: log, fails: boom})
+	if len(log.get()) != 0 {
+		t.Fatalf("Expected components started by Start, got %v", log.get())
+	}
+	if err := b.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	err := b.Close(context.Background())
+	if !errors.Is(err, boom) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected stop errors reported, got %v", err)
+	}
+	want := []string{"start bridge", "start watcher", "start ticker", "stop ticker", "stop watcher", "stop bridge"}
+	if got := log.get(); !slices.Equal(got, want) {
+		t.Fatalf("Unexpected order: %v", got)
+	}
+	if err := b.Manage(&source{log: log}); !errors.Is(err, bus.ErrClosed) {
+		t.Fatalf("Expected ErrClosed, got %v", err)
+	}
+}
+
+func TestManage_StartsRunningBus(t *testing.T) {
+	b := bus.New()
+	log := &journal{}
+	if err := b.Manage(&source{name: "pool", log: log}); err != nil || len(log.get()) != 1 {
+		t.Fatalf("Expected immediate start, got %v, %v", err, log.get())
+	}
+	if err := b.Close(context.Background()); err != nil || len(log.get()) != 2 {
+		t.Fatalf("Expected stop on Close, got %v, %v", err, log.get())
+	}
+}
@@ -0,0 +1,41 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync/atomic"
+)
+
+type forwardKey struct{}
+
+var forwardRules atomic.Uint64
+
+// forward marks ctx as having gone through the forwarding rule id,
+// reporting false if it already had.
+func forward(ctx context.Context, id uint64) (context.Context, bool) {
+	applied, _ := ctx.Value(forwardKey{}).([]uint64)
+	if slices.Contains(applied, id) {
+		return ctx, false
+	}
+	return context.WithValue(ctx, forwardKey{}, append(applied[:len(applied):len(applied)], id)), true
+}
+
+// Convert forwards every A emitted on src to dst as the B returned by fn,
+// so that bounded contexts with different event shapes can interoperate.
+// A conversion is applied at most once along a chain of dispatches, which
+// stops loops such as an A to B rule paired with a B to A rule. Cancel the
+// returned subscription to remove the rule.
+func Convert[A, B any](src, dst *Bus, fn func(A) B, opts ...SubscribeOption) *Subscription {
+	id := forwardRules.Add(1)
+	name := fmt.Sprintf("bus.Convert[%s, %s]", reflect.TypeFor[A](), reflect.TypeFor[B]())
+	opts = append([]SubscribeOption{WithName(name)}, opts...)
+	return Subscribe(src, func(ctx context.Context, event A) error {
+		ctx, ok := forward(ctx, id)
+		if !ok {
+			return nil
+		}
+		return Emit(ctx, dst, fn(event))
+	}, opts...)
+}
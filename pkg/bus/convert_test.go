@@ -0,0 +1,42 @@
+package bus_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type LegacyOrder struct {
+	Number int
+}
+
+func TestConvert(t *testing.T) {
+	orders, legacy := bus.New(), bus.New()
+	var got []int
+	bus.Subscribe(legacy, func(ctx context.Context, e LegacyOrder) error {
+		got = append(got, e.Number)
+		return nil
+	})
+	toLegacy := bus.Convert(orders, legacy, func(e OrderCreated) LegacyOrder {
+		return LegacyOrder{Number: e.ID}
+	})
+	// The reverse rule would loop forever without protection.
+	bus.Convert(legacy, orders, func(e LegacyOrder) OrderCreated {
+		return OrderCreated{ID: e.Number}
+	})
+
+	ctx := context.Background()
+	if err := bus.Emit(ctx, orders, OrderCreated{ID: 7}); err != nil {
+		t.Fatalf("Emit failed: %v", err)
+	}
+	if len(got) != 1 || got[0] != 7 {
+		t.Fatalf("Unexpected conversions: %v", got)
+	}
+
+	toLegacy.Cancel()
+	_ = bus.Emit(ctx, orders, OrderCreated{ID: 8})
+	if len(got) != 1 {
+		t.Fatalf("Expected no conversion after Cancel, got %v", got)
+	}
+}
@@ -0,0 +1,29 @@
+package bus
+
+import "context"
+
+// DeadLetter receives failed deliveries, synchronous or not, so that
+// failed events can be inspected or re-emitted. Passed to Subscribe, a
+// DeadLetter receives the failures of that handler instead of the
+// dead letter of the bus.
+type DeadLetter func(ctx context.Context, failed DispatchError)
+
+func (d DeadLetter) applySubscribe(s *subscriber) { s.deadLetter = d }
+
+// WithDeadLetter routes every failed delivery on the bus to d, except for
+// subscriptions with their own DeadLetter.
+func WithDeadLetter(d DeadLetter) Option {
+	return func(b *Bus) { b.deadLetter = d }
+}
+
+// fail reports a failed delivery to s and routes it to its dead letter.
+func (b *Bus) fail(ctx context.Context, s *subscriber, e DispatchError) {
+	b.reportError(e)
+	d := s.deadLetter
+	if d == nil {
+		d = b.deadLetter
+	}
+	if d != nil {
+		d(ctx, e)
+	}
+}
@@ -0,0 +1,53 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestDeadLetter_Subscription(t *testing.T) {
+	b := bus.New(bus.WithStrategy(bus.BestEffort))
+	boom := errors.New("boom")
+	var letters []bus.DispatchError
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		return boom
+	}, bus.DeadLetter(func(ctx context.Context, failed bus.DispatchError) {
+		letters = append(letters, failed)
+	}))
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		return errors.New("not routed")
+	})
+
+	_ = bus.Emit(context.Background(), b, OrderCreated{ID: 4})
+	if len(letters) != 1 || !errors.Is(letters[0], boom) || letters[0].Event.(OrderCreated).ID != 4 {
+		t.Fatalf("Unexpected dead letters: %v", letters)
+	}
+}
+
+func TestDeadLetter_Bus(t *testing.T) {
+	var global, own []bus.DispatchError
+	b := bus.New(bus.WithDeadLetter(func(ctx context.Context, failed bus.DispatchError) {
+		global = append(global, failed)
+	}))
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		return errors.New("boom")
+	})
+	bus.Subscribe(b, func(ctx context.Context, e InvoiceIssued) error {
+		return errors.New("boom")
+	}, bus.DeadLetter(func(ctx context.Context, failed bus.DispatchError) {
+		own = append(own, failed)
+	}))
+
+	bus.EmitFuture(context.Background(), b, OrderCreated{}).Wait()
+	_ = bus.Emit(context.Background(), b, InvoiceIssued{})
+
+	if len(global) != 1 || !global[0].Async {
+		t.Fatalf("Expected the async failure in the bus dead letter, got %v", global)
+	}
+	if len(own) != 1 {
+		t.Fatalf("Expected the override to receive its failure, got %v", own)
+	}
+}
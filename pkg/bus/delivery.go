@@ -0,0 +1,62 @@
+package bus
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Delivery describes the invocation of a handler.
+type Delivery struct {
+	// ID is unique to the invocation within the process.
+	ID uint64
+	// Attempt counts the invocations of the handler for the same event,
+	// starting at 1.
+	Attempt   int
+	Priority  Priority
+	EmittedAt time.Time
+	// Topic is the topic the event was emitted on, if any.
+	Topic string
+	// LastError is the error of the previous attempt, if any.
+	LastError error
+	// Event identifies the event being delivered.
+	Event Origin
+	// Provenance lists the events that led to Event, from the one that
+	// started the cascade to the one whose handler emitted Event.
+	Provenance []Origin
+}
+
+type deliveryKey struct{}
+
+var deliveryIDs atomic.Uint64
+
+// DeliveryFrom returns the delivery carried by the context of a handler.
+func DeliveryFrom(ctx context.Context) (Delivery, bool) {
+	d, ok := ctx.Value(deliveryKey{}).(Delivery)
+	return d, ok
+}
+
+func withDelivery(ctx context.Context, s *subscriber, env envelope, attempt int, lastErr error) context.Context {
+	return context.WithValue(ctx, deliveryKey{}, Delivery{
+		ID:         deliveryIDs.Add(1),
+		Attempt:    attempt,
+		Priority:   s.priority,
+		EmittedAt:  env.emitted,
+		Topic:      env.topic,
+		LastError:  lastErr,
+		Event:      Origin{ID: env.id, Type: env.key},
+		Provenance: env.cause,
+	})
+}
+
+// RemainingBudget returns the time left before the deadline of ctx, which
+// in a handler accounts for the time taken by the handlers that ran before
+// it. It reports false when ctx has no deadline. Handlers can use it to
+// size the timeouts of their own downstream calls.
+func RemainingBudget(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return max(time.Until(deadline), 0), true
+}
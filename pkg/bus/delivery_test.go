@@ -0,0 +1,105 @@
+package bus_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestDeliveryFrom(t *testing.T) {
+	b := bus.New()
+	var got []bus.Delivery
+	record := func(ctx context.Context, e *Event) error {
+		d, ok := bus.DeliveryFrom(ctx)
+		if !ok {
+			t.Error("Expected delivery in handler context")
+		}
+		got = append(got, d)
+		return nil
+	}
+	bus.Subscribe(b, record, bus.PriorityHigh)
+	bus.Subscribe(b, record)
+
+	before := time.Now()
+	_ = bus.Emit(context.Background(), b, &Event{})
+
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 deliveries, got %d", len(got))
+	}
+	if got[0].ID == got[1].ID {
+		t.Fatal("Expected distinct delivery IDs")
+	}
+	if got[0].Priority != bus.PriorityHigh || got[0].Attempt != 1 {
+		t.Fatalf("Unexpected delivery: %+v", got[0])
+	}
+	if got[0].EmittedAt.Before(before) || !got[0].EmittedAt.Equal(got[1].EmittedAt) {
+		t.Fatalf("Unexpected emit times: %v, %v", got[0].EmittedAt, got[1].EmittedAt)
+	}
+
+	if _, ok := bus.DeliveryFrom(context.Background()); ok {
+		t.Fatal("Expected no delivery outside handlers")
+	}
+}
+
+func TestRemainingBudget(t *testing.T) {
+	b := bus.New()
+	var budgets []time.Duration
+	record := func(ctx context.Context, e *Event) error {
+		budget, ok := bus.RemainingBudget(ctx)
+		if !ok {
+			t.Error("Expected a budget")
+		}
+		budgets = append(budgets, budget)
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}
+	bus.Subscribe(b, record)
+	bus.Subscribe(b, record)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_ = bus.Emit(ctx, b, &Event{})
+
+	if len(budgets) != 2 || budgets[1] > budgets[0]-20*time.Millisecond {
+		t.Fatalf("Expected the second handler to see a smaller budget, got %v", budgets)
+	}
+	if _, ok := bus.RemainingBudget(context.Background()); ok {
+		t.Fatal("Expected no budget without a deadline")
+	}
+}
+
+func TestDelivery_Provenance(t *testing.T) {
+	b := bus.New()
+	ctx := context.Background()
+
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		return bus.Emit(ctx, b, InvoiceIssued{})
+	})
+	bus.Subscribe(b, func(ctx context.Context, e InvoiceIssued) error {
+		return bus.Emit(ctx, b, Tick{})
+	})
+	var root, leaf bus.Delivery
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		root, _ = bus.DeliveryFrom(ctx)
+		return nil
+	}, bus.PriorityHigh)
+	bus.Subscribe(b, func(ctx context.Context, e Tick) error {
+		leaf, _ = bus.DeliveryFrom(ctx)
+		return nil
+	})
+
+	if err := bus.Emit(ctx, b, OrderCreated{}); err != nil {
+		t.Fatalf("Emit failed: %v", err)
+	}
+	if len(root.Provenance) != 0 {
+		t.Fatalf("Expected no ancestors for the root event, got %v", root.Provenance)
+	}
+	chain := leaf.Provenance
+	if len(chain) != 2 || chain[0] != root.Event ||
+		chain[1].Type != reflect.TypeFor[InvoiceIssued]() || leaf.Event.Type != reflect.TypeFor[Tick]() {
+		t.Fatalf("Unexpected provenance: %+v -> %+v", chain, leaf.Event)
+	}
+}
@@ -0,0 +1,82 @@
+package bus
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrDependencyCycle is reported by Subscription.Err when the handlers a
+// subscription runs after, directly or not, run after it.
+var ErrDependencyCycle = errors.New("bus: handler dependency cycle")
+
+// WithAfter makes the handler run after the handlers named names with
+// WithName, when they have the same priority, both on dispatch and on
+// Bus.Start. Priorities still take precedence, so ordering across teams
+// does not need to be expressed in numbers.
+func WithAfter(names ...string) SubscribeOption {
+	return subscribeOption(func(s *subscriber) { s.after = append(s.after, names...) })
+}
+
+// arrange sorts subs by priority, then by subscription order, moving
+// handlers after those they depend on within the same priority. It
+// reports cycles, leaving the handlers on them in subscription order.
+func arrange(subs []*subscriber) error {
+	slices.SortStableFunc(subs, func(a, b *subscriber) int {
+		if a.priority != b.priority {
+			return cmp.Compare(b.priority, a.priority)
+		}
+		return cmp.Compare(a.seq, b.seq)
+	})
+	var errs []error
+	for i := 0; i < len(subs); {
+		j := i + 1
+		for j < len(subs) && subs[j].priority == subs[i].priority {
+			j++
+		}
+		errs = append(errs, topoSort(subs[i:j]))
+		i = j
+	}
+	return errors.Join(errs...)
+}
+
+// topoSort orders a group of handlers of equal priority so that each runs
+// after its dependencies, otherwise keeping the order of the group.
+func topoSort(group []*subscriber) error {
+	if !slices.ContainsFunc(group, func(s *subscriber) bool { return len(s.after) > 0 }) {
+		return nil
+	}
+	pending := slices.Clone(group)
+	out := group[:0:0]
+	ready := func(s *subscriber) bool {
+		return !slices.ContainsFunc(pending, func(o *subscriber) bool {
+			return o.name != "" && slices.Contains(s.after, o.name)
+		})
+	}
+	for len(pending) > 0 {
+		i := slices.IndexFunc(pending, ready)
+		if i < 0 {
+			names := make([]string, len(pending))
+			for k, s := range pending {
+				names[k] = s.info().Name
+			}
+			out = append(out, pending...)
+			copy(group, out)
+			return fmt.Errorf("%w: %v", ErrDependencyCycle, names)
+		}
+		out = append(out, pending[i])
+		pending = slices.Delete(pending, i, i+1)
+	}
+	copy(group, out)
+	return nil
+}
+
+// checkDependencies reports whether subscribing s would close a cycle
+// among the handlers of b.
+func (b *Bus) checkDependencies(s *subscriber) error {
+	if len(s.after) == 0 || s.name == "" {
+		return nil
+	}
+	return arrange(append(b.ordered(), s))
+}
@@ -0,0 +1,41 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestWithAfter(t *testing.T) {
+	b := bus.New()
+	var order []string
+	handler := func(name string) bus.Handler[OrderCreated] {
+		return func(ctx context.Context, e OrderCreated) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+	bus.Subscribe(b, handler("notifier"), bus.WithName("notifier"), bus.WithAfter("billing.writer", "ledger"))
+	bus.Subscribe(b, handler("ledger"), bus.WithName("ledger"), bus.WithAfter("billing.writer"))
+	bus.Subscribe(b, handler("billing.writer"), bus.WithName("billing.writer"))
+	bus.Subscribe(b, handler("audit"), bus.WithName("audit"), bus.PriorityHigh, bus.WithAfter("notifier"))
+
+	_ = bus.Emit(context.Background(), b, OrderCreated{})
+	want := []string{"audit", "billing.writer", "ledger", "notifier"}
+	if !slices.Equal(order, want) {
+		t.Fatalf("Expected %v, got %v", want, order)
+	}
+
+	cycle := bus.Subscribe(b, handler("billing.writer"), bus.WithName("billing.writer"), bus.WithAfter("notifier"))
+	if !errors.Is(cycle.Err(), bus.ErrDependencyCycle) || cycle.Active() {
+		t.Fatalf("Expected ErrDependencyCycle, got %v", cycle.Err())
+	}
+	order = nil
+	_ = bus.Emit(context.Background(), b, OrderCreated{})
+	if !slices.Equal(order, want) {
+		t.Fatalf("Expected rejected subscription not to run, got %v", order)
+	}
+}
@@ -0,0 +1,41 @@
+package bus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var ErrDeprecated = errors.New("bus: deprecated event type")
+
+// Deprecate marks the event type T as deprecated. Subscribing to or
+// emitting T logs reason as a warning; in strict mode both fail with
+// ErrDeprecated instead.
+func Deprecate[T any](b *Bus, reason string) {
+	if b == nil {
+		b = defaultBus
+	}
+	b.deprecated.Set(reflect.TypeFor[T](), reason)
+}
+
+// DeprecationOf returns the deprecation reason of the event type T, if any.
+func DeprecationOf[T any](b *Bus) (string, bool) {
+	if b == nil {
+		b = defaultBus
+	}
+	return b.deprecated.Get(reflect.TypeFor[T]())
+}
+
+// checkDeprecated logs subscriptions to the deprecated event type key,
+// rejecting them with ErrDeprecated in strict mode.
+func (b *Bus) checkDeprecated(key reflect.Type) error {
+	reason, ok := b.deprecated.Get(key)
+	if !ok {
+		return nil
+	}
+	if b.strict {
+		return fmt.Errorf("%w: %s: %s", ErrDeprecated, key, reason)
+	}
+	b.logger.Warn("bus: subscribed to deprecated event type", "type", key.String(), "reason", reason)
+	return nil
+}
@@ -0,0 +1,67 @@
+package bus_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type OrderCreated struct {
+	ID int
+}
+
+func TestDeprecate_Warns(t *testing.T) {
+	var buf bytes.Buffer
+	b := bus.New(bus.WithLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+	bus.Deprecate[OrderCreated](b, "use OrderCreatedV2")
+
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error { return nil })
+	if !strings.Contains(buf.String(), "use OrderCreatedV2") {
+		t.Fatalf("Expected subscribe warning, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := bus.Emit(context.Background(), b, OrderCreated{ID: 1}); err != nil {
+		t.Fatalf("Emit failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "deprecated event type emitted") {
+		t.Fatalf("Expected emit warning, got %q", buf.String())
+	}
+}
+
+func TestDeprecate_StrictRejects(t *testing.T) {
+	b := bus.New(bus.WithStrict(), bus.WithLogger(slog.New(slog.DiscardHandler)))
+	bus.Deprecate[OrderCreated](b, "use OrderCreatedV2")
+
+	called := false
+	sub := bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		called = true
+		return nil
+	})
+	if !errors.Is(sub.Err(), bus.ErrDeprecated) || sub.Active() {
+		t.Fatalf("Expected a cancelled subscription failing with ErrDeprecated, got %v", sub.Err())
+	}
+	answer := func(ctx context.Context, e OrderCreated) (int, error) { return e.ID, nil }
+	for _, sub := range []*bus.Subscription{
+		bus.SubscribeTopic(b, "orders", func(ctx context.Context, e OrderCreated) error { return nil }),
+		bus.Respond(b, answer),
+		bus.Contribute(b, answer),
+	} {
+		if !errors.Is(sub.Err(), bus.ErrDeprecated) {
+			t.Fatalf("Expected ErrDeprecated, got %v", sub.Err())
+		}
+	}
+
+	err := bus.Emit(context.Background(), b, OrderCreated{ID: 1})
+	if !errors.Is(err, bus.ErrDeprecated) {
+		t.Fatalf("Expected ErrDeprecated, got %v", err)
+	}
+	if called {
+		t.Fatal("Handler called for deprecated event in strict mode")
+	}
+}
@@ -0,0 +1,59 @@
+package bus
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned for emissions and subscriptions on a closed bus.
+var ErrClosed = errors.New("bus: closed")
+
+// gate counts the emissions accepted by the bus so Close can wait for
+// them to drain.
+type gate struct {
+	mu     sync.Mutex
+	active int
+	closed bool
+	idle   chan struct{}
+}
+
+// enter admits an emission, reporting false once the gate is shut.
+func (g *gate) enter() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.closed {
+		return false
+	}
+	g.active++
+	return true
+}
+
+func (g *gate) leave() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.active--
+	if g.active == 0 && g.closed {
+		close(g.idle)
+	}
+}
+
+func (g *gate) isClosed() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.closed
+}
+
+// shut stops admitting emissions and returns a channel closed once the
+// admitted ones have completed.
+func (g *gate) shut() <-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.closed {
+		g.closed = true
+		g.idle = make(chan struct{})
+		if g.active == 0 {
+			close(g.idle)
+		}
+	}
+	return g.idle
+}
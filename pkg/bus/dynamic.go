@@ -0,0 +1,54 @@
+package bus
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// SubscribeType subscribes fn to events of typ, for callers that only know
+// the event type at runtime, such as plugins. It pairs with EmitAny, and
+// receives the events emitted with Emit for typ as well.
+func SubscribeType(b *Bus, typ reflect.Type, fn Handler[any], opts ...SubscribeOption) *Subscription {
+	if b == nil {
+		b = defaultBus
+	}
+	sub := newSubscriber(fn, opts)
+	sub.key = typ
+	return b.subscribe(sub)
+}
+
+// dispatchTable caches, per event type, the handlers a dispatch runs.
+// Snapshots are immutable and swapped atomically, so lookups take no lock
+// and static and dynamic types resolve alike. Any subscription change
+// discards the snapshot, so types no longer emitted, like those of an
+// unloaded plugin, are not carried over.
+type dispatchTable struct {
+	snap atomic.Pointer[map[reflect.Type][]*subscriber]
+}
+
+func (t *dispatchTable) invalidate() {
+	t.snap.Store(&map[reflect.Type][]*subscriber{})
+}
+
+// handlersOf returns the handlers of key from the dispatch table, resolving
+// and caching them on a miss.
+func (b *Bus) handlersOf(key reflect.Type) []*subscriber {
+	cur := b.table.snap.Load()
+	if cur != nil {
+		if subs, ok := (*cur)[key]; ok {
+			return subs
+		}
+	}
+	subs := b.resolve(key)
+	next := make(map[reflect.Type][]*subscriber, 1)
+	if cur != nil {
+		for k, v := range *cur {
+			next[k] = v
+		}
+	}
+	next[key] = subs
+	// A failed swap means the table was invalidated meanwhile: drop the
+	// entry rather than caching a possibly stale list.
+	b.table.snap.CompareAndSwap(cur, &next)
+	return subs
+}
@@ -0,0 +1,66 @@
+package bus_test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestSubscribeType(t *testing.T) {
+	b := bus.New()
+	ctx := context.Background()
+	var got []any
+	sub := bus.SubscribeType(b, reflect.TypeFor[OrderCreated](), func(ctx context.Context, e any) error {
+		got = append(got, e)
+		return nil
+	})
+
+	_ = bus.EmitAny(ctx, b, OrderCreated{ID: 1})
+	_ = bus.Emit(ctx, b, OrderCreated{ID: 2})
+	_ = bus.EmitAny(ctx, b, Tick{})
+	if len(got) != 2 || got[0].(OrderCreated).ID != 1 || got[1].(OrderCreated).ID != 2 {
+		t.Fatalf("Unexpected deliveries: %v", got)
+	}
+
+	sub.Cancel()
+	_ = bus.EmitAny(ctx, b, OrderCreated{ID: 3})
+	if len(got) != 2 {
+		t.Fatalf("Expected no delivery after Cancel, got %v", got)
+	}
+}
+
+func TestDispatchTable_ConcurrentChurn(t *testing.T) {
+	b := bus.New()
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				sub := bus.Subscribe(b, func(ctx context.Context, e Tick) error { return nil })
+				sub.Cancel()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				_ = bus.EmitAny(ctx, b, Tick{})
+			}
+		}()
+	}
+	wg.Wait()
+
+	calls := 0
+	bus.Subscribe(b, func(ctx context.Context, e Tick) error {
+		calls++
+		return nil
+	})
+	_ = bus.EmitAny(ctx, b, Tick{})
+	if calls != 1 {
+		t.Fatalf("Expected the new handler to be reached once, got %d", calls)
+	}
+}
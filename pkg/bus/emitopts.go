@@ -0,0 +1,44 @@
+package bus
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/mirkobrombin/go-foundation/pkg/options"
+)
+
+// EmitOption overrides the bus configuration for a single emission.
+type EmitOption = options.Option[envelope]
+
+// EmitWithStrategy runs the handlers of the emission with s instead of
+// the bus strategy.
+func EmitWithStrategy(s Strategy) EmitOption {
+	return func(e *envelope) { e.strategy = s }
+}
+
+// EmitWithTimeout bounds the dispatch of the emission to d.
+func EmitWithTimeout(d time.Duration) EmitOption {
+	return func(e *envelope) { e.timeout = d }
+}
+
+// EmitWithAsync dispatches the emission in the background, as EmitAsync.
+func EmitWithAsync() EmitOption {
+	return func(e *envelope) { e.async = true }
+}
+
+// EmitOpts is like Emit with opts applied to this emission only. Async
+// emissions return once scheduled; their errors go to the async error
+// callback.
+func EmitOpts[T any](ctx context.Context, b *Bus, event T, opts ...EmitOption) error {
+	if b == nil {
+		b = defaultBus
+	}
+	env := newEnvelope(reflect.TypeFor[T](), event)
+	options.Apply(&env, opts...)
+	if env.async {
+		emitAsync(ctx, b, env)
+		return nil
+	}
+	return b.send(ctx, env)
+}
@@ -0,0 +1,60 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestEmitOpts(t *testing.T) {
+	b := bus.New()
+	ctx := context.Background()
+	boom := errors.New("boom")
+	calls := 0
+	for range 2 {
+		bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+			calls++
+			return boom
+		})
+	}
+
+	if err := bus.Emit(ctx, b, &Event{}); !errors.Is(err, boom) || calls != 1 {
+		t.Fatalf("Expected bus strategy to stop early, got %v after %d calls", err, calls)
+	}
+	calls = 0
+	if err := bus.EmitOpts(ctx, b, &Event{}, bus.EmitWithStrategy(bus.BestEffort)); !errors.Is(err, boom) || calls != 2 {
+		t.Fatalf("Expected override to reach both handlers, got %v after %d calls", err, calls)
+	}
+}
+
+func TestEmitOpts_TimeoutAndAsync(t *testing.T) {
+	b := bus.New()
+	ctx := context.Background()
+	done := make(chan error, 1)
+	bus.Subscribe(b, func(ctx context.Context, e Tick) error {
+		<-ctx.Done()
+		done <- ctx.Err()
+		return ctx.Err()
+	})
+
+	err := bus.EmitOpts(ctx, b, Tick{}, bus.EmitWithTimeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded, got %v", err)
+	}
+	<-done
+
+	if err := bus.EmitOpts(ctx, b, Tick{}, bus.EmitWithAsync(), bus.EmitWithTimeout(10*time.Millisecond)); err != nil {
+		t.Fatalf("Expected async emission to be scheduled, got %v", err)
+	}
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Expected deadline exceeded in background, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for async emission")
+	}
+}
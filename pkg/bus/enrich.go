@@ -0,0 +1,35 @@
+package bus
+
+import (
+	"context"
+	"reflect"
+)
+
+type enricher func(ctx context.Context, event any) any
+
+// Enrich registers fn to run on every emitted event of type T before it is
+// dispatched, so derived fields are filled once for all handlers.
+// Enrichers run in registration order.
+func Enrich[T any](b *Bus, fn func(ctx context.Context, event *T)) {
+	if b == nil {
+		b = defaultBus
+	}
+	b.enrichers.Compute(reflect.TypeFor[T](), func(fns []enricher, _ bool) []enricher {
+		return append(fns, func(ctx context.Context, event any) any {
+			v := event.(T)
+			fn(ctx, &v)
+			return v
+		})
+	})
+}
+
+func (b *Bus) enrich(ctx context.Context, key reflect.Type, event any) any {
+	fns, ok := b.enrichers.Get(key)
+	if !ok {
+		return event
+	}
+	for _, fn := range fns {
+		event = fn(ctx, event)
+	}
+	return event
+}
@@ -0,0 +1,46 @@
+package bus_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type Visit struct {
+	IP      string
+	Country string
+	Label   string
+}
+
+func TestEnrich(t *testing.T) {
+	b := bus.New()
+	lookups := 0
+
+	bus.Enrich(b, func(ctx context.Context, e *Visit) {
+		lookups++
+		e.Country = "IT"
+	})
+	bus.Enrich(b, func(ctx context.Context, e *Visit) {
+		e.Label = e.IP + "@" + e.Country
+	})
+
+	var got []Visit
+	for i := 0; i < 2; i++ {
+		bus.Subscribe(b, func(ctx context.Context, e Visit) error {
+			got = append(got, e)
+			return nil
+		})
+	}
+
+	_ = bus.Emit(context.Background(), b, Visit{IP: "1.2.3.4"})
+
+	if lookups != 1 {
+		t.Fatalf("Expected enricher to run once, got %d", lookups)
+	}
+	for _, v := range got {
+		if v.Label != "1.2.3.4@IT" {
+			t.Fatalf("Unexpected enriched event: %+v", v)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package bus
+
+import (
+	"reflect"
+	"time"
+)
+
+// envelope carries an event through the dispatch pipeline together with
+// the metadata of its emission.
+type envelope struct {
+	id     uint64
+	cause  []Origin
+	key    reflect.Type
+	event  any
+	topic  string
+	tenant string
+	// producer is resolved when the emission is made, for those
+	// dispatched on another goroutine.
+	producer string
+	async    bool
+	sticky   bool
+	emitted  time.Time
+
+	strategy Strategy
+	timeout  time.Duration
+	report   *reporter
+}
+
+func newEnvelope(key reflect.Type, event any) envelope {
+	return envelope{id: eventIDs.Add(1), key: key, event: event, emitted: time.Now()}
+}
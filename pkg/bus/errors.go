@@ -0,0 +1,93 @@
+package bus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var ErrNilEvent = errors.New("bus: nil event")
+
+const errorsBuffer = 64
+
+// DispatchError describes a single handler failure.
+type DispatchError struct {
+	// Handler describes the handler that failed.
+	Handler  HandlerInfo
+	Type     reflect.Type
+	Event    any
+	Priority Priority
+	Async    bool
+	Err      error
+}
+
+func (e DispatchError) Error() string {
+	if e.Handler.Name == "" {
+		return fmt.Sprintf("bus: handler for %s failed: %v", e.Type, e.Err)
+	}
+	return fmt.Sprintf("bus: handler %s for %s failed: %v", e.Handler.Name, e.Type, e.Err)
+}
+
+func (e DispatchError) Unwrap() error {
+	return e.Err
+}
+
+// HandlerError attributes the error returned by Emit for a failed handler
+// to the handler, named after WithName or its function.
+type HandlerError struct {
+	Name string
+	Type reflect.Type
+	Err  error
+}
+
+func (e *HandlerError) Error() string {
+	return fmt.Sprintf("bus: handler %s for %s: %v", e.Name, e.Type, e.Err)
+}
+
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// Errors returns a channel streaming every handler failure on the bus,
+// both from Emit and EmitAsync. The channel is buffered; failures are
+// dropped when the consumer falls behind. It is closed once Close has
+// drained the bus.
+func Errors(b *Bus) <-chan DispatchError {
+	if b == nil {
+		b = defaultBus
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.errs == nil {
+		b.errs = make(chan DispatchError, errorsBuffer)
+		if b.errsClosed {
+			close(b.errs)
+		}
+	}
+	return b.errs
+}
+
+func (b *Bus) reportError(e DispatchError) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.errs == nil || b.errsClosed {
+		return
+	}
+	select {
+	case b.errs <- e:
+	default:
+	}
+}
+
+// closeErrors closes the Errors channel; later failures are not reported.
+func (b *Bus) closeErrors() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.errsClosed {
+		return
+	}
+	b.errsClosed = true
+	if b.errs != nil {
+		close(b.errs)
+	}
+}
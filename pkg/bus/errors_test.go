@@ -0,0 +1,87 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestErrors_Stream(t *testing.T) {
+	b := bus.New(bus.WithStrategy(bus.BestEffort))
+	errs := bus.Errors(b)
+	boom := errors.New("boom")
+
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		return boom
+	})
+
+	_ = bus.Emit(context.Background(), b, &Event{Greeting: "sync"})
+	bus.EmitAsync(context.Background(), b, &Event{Greeting: "async"})
+
+	for _, async := range []bool{false, true} {
+		select {
+		case de := <-errs:
+			if !errors.Is(de, boom) {
+				t.Fatalf("Expected boom, got %v", de.Err)
+			}
+			if de.Async != async {
+				t.Fatalf("Expected Async=%v, got %v", async, de.Async)
+			}
+			if de.Event.(*Event).Greeting == "" {
+				t.Fatal("Expected event in dispatch error")
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("Timeout waiting for dispatch error")
+		}
+	}
+}
+
+func TestErrors_ClosedOnClose(t *testing.T) {
+	b := bus.New()
+	errs := bus.Errors(b)
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		return errors.New("boom")
+	})
+	bus.EmitAsync(context.Background(), b, &Event{})
+
+	drained := make(chan int)
+	go func() {
+		n := 0
+		for range errs {
+			n++
+		}
+		drained <- n
+	}()
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	select {
+	case n := <-drained:
+		if n != 1 {
+			t.Fatalf("Expected 1 failure before the channel closed, got %d", n)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Errors channel not closed by Close")
+	}
+	if _, ok := <-bus.Errors(b); ok {
+		t.Fatal("Expected a closed channel after Close")
+	}
+}
+
+func TestErrors_Handler(t *testing.T) {
+	b := bus.New()
+	errs := bus.Errors(b)
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		return errors.New("boom")
+	}, bus.WithName("billing.invoicer"))
+
+	_ = bus.Emit(context.Background(), b, &Event{})
+	de := <-errs
+	if de.Handler.Name != "billing.invoicer" || !strings.Contains(de.Error(), "billing.invoicer") {
+		t.Fatalf("Expected the failing handler in the error, got %+v", de)
+	}
+}
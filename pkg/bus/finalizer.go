@@ -0,0 +1,47 @@
+package bus
+
+import (
+	"context"
+	"runtime/debug"
+)
+
+// Finalizer is called after a handler invocation with the event and the
+// outcome of the invocation. A handler panic is passed as a *PanicError,
+// whatever the RecoveryPolicy; a timed out handler, as
+// context.DeadlineExceeded, possibly while it is still running.
+type Finalizer func(ctx context.Context, event any, err error)
+
+// WithFinalizer calls fn after every invocation of the handler.
+func WithFinalizer(fn Finalizer) SubscribeOption {
+	return subscribeOption(func(s *subscriber) { s.finalizers = append(s.finalizers, fn) })
+}
+
+// WithGlobalFinalizer calls fn after every handler invocation on the bus,
+// after the finalizers of the subscription.
+func WithGlobalFinalizer(fn Finalizer) Option {
+	return func(b *Bus) { b.finalizers = append(b.finalizers, fn) }
+}
+
+// finalize runs the finalizers of s once the invocation is over. It is
+// deferred before recoverPanic so that it observes the recovered error.
+func (b *Bus) finalize(ctx context.Context, s *subscriber, event any, err *error) {
+	if len(s.finalizers) == 0 && len(b.finalizers) == 0 {
+		return
+	}
+	if b.recovery == Repanic {
+		if v := recover(); v != nil {
+			b.runFinalizers(ctx, s, event, &PanicError{Handler: s.info(), Value: v, Stack: debug.Stack()})
+			panic(v)
+		}
+	}
+	b.runFinalizers(ctx, s, event, *err)
+}
+
+func (b *Bus) runFinalizers(ctx context.Context, s *subscriber, event any, err error) {
+	for _, fn := range s.finalizers {
+		fn(ctx, event, err)
+	}
+	for _, fn := range b.finalizers {
+		fn(ctx, event, err)
+	}
+}
@@ -0,0 +1,75 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestFinalizer(t *testing.T) {
+	var local, global []error
+	b := bus.New(
+		bus.WithRecovery(bus.RecoverAsError),
+		bus.WithGlobalFinalizer(func(ctx context.Context, event any, err error) {
+			global = append(global, err)
+		}),
+	)
+	boom := errors.New("boom")
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		switch e.Greeting {
+		case "fail":
+			return boom
+		case "panic":
+			panic("oops")
+		}
+		return nil
+	}, bus.WithFinalizer(func(ctx context.Context, event any, err error) {
+		local = append(local, err)
+	}))
+
+	for _, g := range []string{"ok", "fail", "panic"} {
+		_ = bus.Emit(context.Background(), b, &Event{Greeting: g})
+	}
+
+	var pe *bus.PanicError
+	if len(local) != 3 || local[0] != nil || !errors.Is(local[1], boom) || !errors.As(local[2], &pe) {
+		t.Fatalf("Unexpected finalizer errors: %v", local)
+	}
+	if len(global) != 3 {
+		t.Fatalf("Expected global finalizer for every invocation, got %v", global)
+	}
+}
+
+func TestFinalizer_RepanicAndTimeout(t *testing.T) {
+	b := bus.New()
+	var got []error
+	finalizer := bus.WithFinalizer(func(ctx context.Context, event any, err error) {
+		got = append(got, err)
+	})
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		panic("oops")
+	}, finalizer)
+	bus.Subscribe(b, func(ctx context.Context, e InvoiceIssued) error {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}, finalizer, bus.WithTimeout(time.Millisecond))
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("Expected the panic to propagate")
+			}
+		}()
+		_ = bus.Emit(context.Background(), b, &Event{})
+	}()
+	_ = bus.Emit(context.Background(), b, InvoiceIssued{})
+
+	var pe *bus.PanicError
+	if len(got) != 2 || !errors.As(got[0], &pe) || !errors.Is(got[1], context.DeadlineExceeded) {
+		t.Fatalf("Unexpected finalizer errors: %v", got)
+	}
+}
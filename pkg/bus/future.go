@@ -0,0 +1,51 @@
+package bus
+
+import (
+	"context"
+	"reflect"
+)
+
+// Future is the outcome of an asynchronous dispatch.
+type Future struct {
+	done chan struct{}
+	err  error
+}
+
+func newFuture() *Future {
+	return &Future{done: make(chan struct{})}
+}
+
+func (f *Future) resolve(err error) {
+	f.err = err
+	close(f.done)
+}
+
+// Done is closed once the dispatch has completed.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait blocks until the dispatch has completed and returns its error.
+func (f *Future) Wait() error {
+	<-f.done
+	return f.err
+}
+
+// Err returns the error of the dispatch, or nil while it is still running.
+func (f *Future) Err() error {
+	select {
+	case <-f.done:
+		return f.err
+	default:
+		return nil
+	}
+}
+
+// EmitFuture is like EmitAsync but returns a Future to await the outcome
+// of the dispatch.
+func EmitFuture[T any](ctx context.Context, b *Bus, event T) *Future {
+	if b == nil {
+		b = defaultBus
+	}
+	return emitAsync(ctx, b, newEnvelope(reflect.TypeFor[T](), event))
+}
@@ -0,0 +1,47 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestEmitFuture(t *testing.T) {
+	b := bus.New()
+	boom := errors.New("boom")
+	release := make(chan struct{})
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		<-release
+		if e.Greeting == "fail" {
+			return boom
+		}
+		return nil
+	})
+
+	ok := bus.EmitFuture(context.Background(), b, &Event{Greeting: "ok"})
+	failed := bus.EmitFuture(context.Background(), b, &Event{Greeting: "fail"})
+	if ok.Err() != nil {
+		t.Fatal("Expected no error while running")
+	}
+	select {
+	case <-ok.Done():
+		t.Fatal("Expected future to be pending")
+	default:
+	}
+	close(release)
+
+	if err := ok.Wait(); err != nil {
+		t.Fatalf("Expected success, got %v", err)
+	}
+	select {
+	case <-failed.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for future")
+	}
+	if !errors.Is(failed.Err(), boom) || !errors.Is(failed.Wait(), boom) {
+		t.Fatalf("Expected boom, got %v", failed.Err())
+	}
+}
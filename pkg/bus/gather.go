@@ -0,0 +1,112 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/mirkobrombin/go-foundation/pkg/options"
+)
+
+// gatherKey identifies the contributors answering queries of one type
+// with results of another.
+type gatherKey struct {
+	query, result reflect.Type
+}
+
+type gatherConfig struct {
+	concurrent bool
+}
+
+// GatherOption configures a single Gather call.
+type GatherOption = options.Option[gatherConfig]
+
+// GatherConcurrently runs the contributors of a Gather call concurrently
+// instead of one after another in priority order.
+func GatherConcurrently() GatherOption {
+	return func(c *gatherConfig) { c.concurrent = true }
+}
+
+// Contribute registers fn as one of the contributors answering Gather
+// calls for queries of type Q with results of type R. Subscribe options
+// apply to the contributor as they do to handlers.
+func Contribute[Q, R any](b *Bus, fn func(ctx context.Context, query Q) (R, error), opts ...SubscribeOption) *Subscription {
+	if b == nil {
+		b = defaultBus
+	}
+	sub := newResponder(fn, opts)
+	sub.seq = b.seq.Add(1)
+	key := gatherKey{query: sub.key, result: reflect.TypeFor[R]()}
+	s := &Subscription{bus: b, key: sub.key, sub: sub}
+	s.remove = func() { b.gatherers.Compute(key, without(sub)) }
+	if b.inflight.isClosed() {
+		sub.cancelled.Store(true)
+		s.err = ErrClosed
+		return s
+	}
+	if err := b.checkDeprecated(sub.key); err != nil {
+		sub.cancelled.Store(true)
+		s.err = err
+		return s
+	}
+	b.gatherers.Compute(key, insert(sub))
+	return s
+}
+
+// Gather sends query to every contributor of Q and R and returns their
+// answers in priority order. All contributors run even when some fail:
+// the answers of those that succeed are returned along with the joined
+// errors of the others. As with Request, queries reach neither the
+// subscribers of Q nor the bus middlewares.
+func Gather[Q, R any](ctx context.Context, b *Bus, query Q, opts ...GatherOption) ([]R, error) {
+	if b == nil {
+		b = defaultBus
+	}
+	if !b.inflight.enter() {
+		return nil, ErrClosed
+	}
+	defer b.inflight.leave()
+
+	var cfg gatherConfig
+	options.Apply(&cfg, opts...)
+	key := reflect.TypeFor[Q]()
+	subs, _ := b.gatherers.Get(gatherKey{query: key, result: reflect.TypeFor[R]()})
+	env := newEnvelope(key, query)
+	replies := make([]reply, len(subs))
+	errs := make([]error, len(subs))
+	ask := func(i int) {
+		ctx := context.WithValue(ctx, replyKey{}, &replies[i])
+		errs[i] = b.deliver(ctx, subs[i], query, env)
+	}
+	if cfg.concurrent {
+		var wg sync.WaitGroup
+		for i := range subs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ask(i)
+			}()
+		}
+		wg.Wait()
+	} else {
+		for i := range subs {
+			ask(i)
+		}
+	}
+
+	var results []R
+	for i := range replies {
+		answer, ok := replies[i].last()
+		if !ok {
+			continue
+		}
+		r, err := answerAs[R](key, answer)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results = append(results, r)
+	}
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,42 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type ListPlugins struct{}
+
+func TestGather(t *testing.T) {
+	b := bus.New()
+	ctx := context.Background()
+	boom := errors.New("boom")
+
+	bus.Contribute(b, func(ctx context.Context, q ListPlugins) (string, error) { return "auth", nil })
+	bus.Contribute(b, func(ctx context.Context, q ListPlugins) (string, error) { return "", boom })
+	first := bus.Contribute(b, func(ctx context.Context, q ListPlugins) (string, error) { return "metrics", nil }, bus.PriorityHigh)
+	bus.Contribute(b, func(ctx context.Context, q ListPlugins) (int, error) { return 42, nil })
+
+	for _, opts := range [][]bus.GatherOption{nil, {bus.GatherConcurrently()}} {
+		got, err := bus.Gather[ListPlugins, string](ctx, b, ListPlugins{}, opts...)
+		if !errors.Is(err, boom) {
+			t.Fatalf("Expected boom, got %v", err)
+		}
+		if !slices.Equal(got, []string{"metrics", "auth"}) {
+			t.Fatalf("Unexpected results: %v", got)
+		}
+	}
+
+	first.Cancel()
+	got, _ := bus.Gather[ListPlugins, string](ctx, b, ListPlugins{})
+	if !slices.Equal(got, []string{"auth"}) {
+		t.Fatalf("Expected cancelled contributor skipped, got %v", got)
+	}
+	if got, err := bus.Gather[ListPlugins, float64](ctx, b, ListPlugins{}); len(got) != 0 || err != nil {
+		t.Fatalf("Expected no answers, got %v, %v", got, err)
+	}
+}
@@ -0,0 +1,83 @@
+package bus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+)
+
+// ErrUnhashable is returned by Hash for events without a deterministic
+// encoding, such as those holding pointers, funcs or channels that JSON
+// cannot encode. Register an encoder for them.
+var ErrUnhashable = errors.New("bus: event cannot be hashed")
+
+// RegisterEncoder sets the canonical encoding of the event type T used by
+// Hash on b. Types without a registered encoder are encoded as JSON.
+func RegisterEncoder[T any](b *Bus, fn func(T) ([]byte, error)) {
+	if b == nil {
+		b = defaultBus
+	}
+	b.encoders.Set(reflect.TypeFor[T](), func(event any) ([]byte, error) {
+		return fn(event.(T))
+	})
+}
+
+// Hash returns a deterministic identity for event, derived from its type
+// and canonical encoding on b. Equal events always hash to the same value,
+// so features keyed on event identity agree with each other.
+func Hash(b *Bus, event any) (uint64, error) {
+	if b == nil {
+		b = defaultBus
+	}
+	typ := reflect.TypeOf(event)
+	data, err := b.encode(typ, event)
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New64a()
+	if typ != nil {
+		h.Write([]byte(typ.String()))
+	}
+	h.Write([]byte{0})
+	h.Write(data)
+	return h.Sum64(), nil
+}
+
+func (b *Bus) encode(typ reflect.Type, event any) ([]byte, error) {
+	if fn, ok := b.encoders.Get(typ); ok {
+		return fn(event)
+	}
+	if data, err := json.Marshal(event); err == nil {
+		return data, nil
+	}
+	if !printable(typ) {
+		return nil, fmt.Errorf("%w: %s", ErrUnhashable, typ)
+	}
+	return []byte(fmt.Sprintf("%#v", event)), nil
+}
+
+// printable reports whether values of typ print the same with %#v
+// whenever they are equal, which rules out addresses and interfaces.
+func printable(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array, reflect.Slice:
+		return printable(typ.Elem())
+	case reflect.Map:
+		return printable(typ.Key()) && printable(typ.Elem())
+	case reflect.Struct:
+		for i := range typ.NumField() {
+			if !printable(typ.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package bus_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type Tick struct {
+	Seq   int
+	Noise string
+}
+
+type Callback struct {
+	Fn func()
+}
+
+func TestHash(t *testing.T) {
+	b := bus.New()
+	hash := func(event any) uint64 {
+		t.Helper()
+		h, err := bus.Hash(b, event)
+		if err != nil {
+			t.Fatalf("Hash failed: %v", err)
+		}
+		return h
+	}
+
+	if hash(Tick{Seq: 1}) != hash(Tick{Seq: 1}) {
+		t.Fatal("Expected equal events to hash equally")
+	}
+	if hash(Tick{Seq: 1}) == hash(Tick{Seq: 2}) {
+		t.Fatal("Expected different events to hash differently")
+	}
+	if hash(InvoiceIssued{ID: 1}) == hash(OrderCreated{ID: 1}) {
+		t.Fatal("Expected the type to be part of the hash")
+	}
+
+	bus.RegisterEncoder(b, func(e Tick) ([]byte, error) {
+		return []byte(strconv.Itoa(e.Seq)), nil
+	})
+	if hash(Tick{Seq: 1, Noise: "a"}) != hash(Tick{Seq: 1, Noise: "b"}) {
+		t.Fatal("Expected registered encoder to define identity")
+	}
+	other, _ := bus.Hash(bus.New(), Tick{Seq: 1, Noise: "a"})
+	if other == hash(Tick{Seq: 1, Noise: "a"}) {
+		t.Fatal("Expected encoders to be scoped to their bus")
+	}
+
+	if _, err := bus.Hash(b, Callback{Fn: func() {}}); !errors.Is(err, bus.ErrUnhashable) {
+		t.Fatalf("Expected ErrUnhashable, got %v", err)
+	}
+	if hash(complex(1, 2)) != hash(complex(1, 2)) {
+		t.Fatal("Expected values JSON cannot encode to hash by their Go syntax")
+	}
+}
@@ -0,0 +1,127 @@
+package bus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/mirkobrombin/go-foundation/pkg/options"
+)
+
+// WebhookDecoder turns the body of an inbound webhook into an event.
+type WebhookDecoder func(r *http.Request, body []byte) (any, error)
+
+// Verifier checks the authenticity of an inbound webhook before it is
+// decoded.
+type Verifier func(r *http.Request, body []byte) error
+
+type ingestConfig struct {
+	verifiers []Verifier
+	maxBody   int64
+}
+
+type IngestOption = options.Option[ingestConfig]
+
+// WithVerifier rejects requests for which v fails with 401 Unauthorized.
+func WithVerifier(v Verifier) IngestOption {
+	return func(c *ingestConfig) { c.verifiers = append(c.verifiers, v) }
+}
+
+// WithMaxBodySize rejects bodies larger than n bytes, 1 MiB by default,
+// with 413 Request Entity Too Large.
+func WithMaxBodySize(n int64) IngestOption {
+	return func(c *ingestConfig) { c.maxBody = n }
+}
+
+// IngestHandler returns an HTTP handler emitting on b the events decoded
+// from inbound webhooks, so that external integrations reuse the bus
+// pipeline. Requests are verified, decoded and emitted synchronously: the
+// response is 204 No Content once the handlers succeeded, 400 Bad Request
+// for undecodable bodies and 500 Internal Server Error for failed
+// dispatches.
+func IngestHandler(b *Bus, decode WebhookDecoder, opts ...IngestOption) http.Handler {
+	if b == nil {
+		b = defaultBus
+	}
+	cfg := &ingestConfig{maxBody: 1 << 20}
+	options.Apply(cfg, opts...)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, cfg.maxBody))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, verify := range cfg.verifiers {
+			if err := verify(r, body); err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+		}
+		event, err := decode(r, body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := EmitAny(r.Context(), b, event); err != nil {
+			b.logger.ErrorContext(r.Context(), "bus: ingested event failed", "type", fmt.Sprintf("%T", event), "err", err)
+			http.Error(w, "dispatch failed", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// DecodeJSON decodes webhook bodies as JSON into a T.
+func DecodeJSON[T any]() WebhookDecoder {
+	return func(_ *http.Request, body []byte) (any, error) {
+		var v T
+		if err := json.Unmarshal(body, &v); err != nil {
+			return nil, fmt.Errorf("bus: decoding %s: %w", reflect.TypeFor[T](), err)
+		}
+		return v, nil
+	}
+}
+
+// DecodeCloudEvents decodes CloudEvents in structured mode, with the
+// event in a JSON body, or in binary mode, with the attributes in Ce-
+// headers and the data in the body. The Go type of the data is the one
+// resolve returns for the CloudEvents type attribute.
+func DecodeCloudEvents(resolve TypeResolver) WebhookDecoder {
+	return func(r *http.Request, body []byte) (any, error) {
+		typ, data := r.Header.Get("Ce-Type"), json.RawMessage(body)
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/cloudevents+json") {
+			var ce struct {
+				Type string          `json:"type"`
+				Data json.RawMessage `json:"data"`
+			}
+			if err := json.Unmarshal(body, &ce); err != nil {
+				return nil, fmt.Errorf("bus: decoding cloudevent: %w", err)
+			}
+			typ, data = ce.Type, ce.Data
+		}
+		t, ok := resolve(typ)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownType, typ)
+		}
+		v := reflect.New(t)
+		if err := json.Unmarshal(data, v.Interface()); err != nil {
+			return nil, fmt.Errorf("bus: decoding %s: %w", t, err)
+		}
+		return v.Elem().Interface(), nil
+	}
+}
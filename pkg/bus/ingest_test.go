@@ -0,0 +1,87 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type PushEvent struct {
+	Ref string `json:"ref"`
+}
+
+func TestIngestHandler(t *testing.T) {
+	b := bus.New()
+	var refs []string
+	bus.Subscribe(b, func(ctx context.Context, e PushEvent) error {
+		if e.Ref == "broken" {
+			return errors.New("boom")
+		}
+		refs = append(refs, e.Ref)
+		return nil
+	})
+	h := bus.IngestHandler(b, bus.DecodeJSON[PushEvent](),
+		bus.WithVerifier(func(r *http.Request, body []byte) error {
+			if r.Header.Get("X-Token") != "secret" {
+				return errors.New("bad token")
+			}
+			return nil
+		}),
+		bus.WithMaxBodySize(64),
+	)
+
+	tests := []struct {
+		method, token, body string
+		want                int
+	}{
+		{http.MethodPost, "secret", `{"ref":"main"}`, http.StatusNoContent},
+		{http.MethodGet, "secret", ``, http.StatusMethodNotAllowed},
+		{http.MethodPost, "wrong", `{"ref":"main"}`, http.StatusUnauthorized},
+		{http.MethodPost, "secret", `{"ref":`, http.StatusBadRequest},
+		{http.MethodPost, "secret", `{"ref":"broken"}`, http.StatusInternalServerError},
+		{http.MethodPost, "secret", `{"ref":"` + strings.Repeat("x", 100) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "/hooks", strings.NewReader(tt.body))
+		req.Header.Set("X-Token", tt.token)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s %s: got %d, want %d", tt.method, tt.body, rec.Code, tt.want)
+		}
+	}
+	if len(refs) != 1 || refs[0] != "main" {
+		t.Fatalf("Unexpected ingested events: %v", refs)
+	}
+}
+
+func TestDecodeCloudEvents(t *testing.T) {
+	decode := bus.DecodeCloudEvents(bus.ResolveTypes(map[string]reflect.Type{
+		"com.github.push": reflect.TypeFor[PushEvent](),
+	}))
+
+	structured := httptest.NewRequest(http.MethodPost, "/", nil)
+	structured.Header.Set("Content-Type", "application/cloudevents+json")
+	v, err := decode(structured, []byte(`{"specversion":"1.0","type":"com.github.push","data":{"ref":"main"}}`))
+	if err != nil || v.(PushEvent).Ref != "main" {
+		t.Fatalf("Unexpected structured decoding: %v, %v", v, err)
+	}
+
+	binary := httptest.NewRequest(http.MethodPost, "/", nil)
+	binary.Header.Set("Ce-Type", "com.github.push")
+	v, err = decode(binary, []byte(`{"ref":"dev"}`))
+	if err != nil || v.(PushEvent).Ref != "dev" {
+		t.Fatalf("Unexpected binary decoding: %v, %v", v, err)
+	}
+
+	binary.Header.Set("Ce-Type", "com.github.star")
+	if _, err := decode(binary, []byte(`{}`)); !errors.Is(err, bus.ErrUnknownType) {
+		t.Fatalf("Expected ErrUnknownType, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package bus
+
+import (
+	"context"
+	"time"
+)
+
+// DispatchFunc delivers an event to the handler described by h.
+type DispatchFunc func(ctx context.Context, event any, h HandlerInfo) error
+
+// Interceptor wraps the delivery of every event to every handler. Unlike a
+// Middleware, which wraps a whole Emit, it sees each handler invocation
+// along with the handler's metadata.
+type Interceptor func(next DispatchFunc) DispatchFunc
+
+// Use appends ic to the interceptors of b. The first registered
+// interceptor is the outermost.
+func Use(b *Bus, ic Interceptor) {
+	if b == nil {
+		b = defaultBus
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.interceptors = append(b.interceptors, ic)
+}
+
+func (b *Bus) deliver(ctx context.Context, s *subscriber, event any, env envelope) error {
+	if s.backlog.hold(ctx, event, env) {
+		return nil
+	}
+	return b.deliverNow(ctx, s, event, env)
+}
+
+func (b *Bus) deliverNow(ctx context.Context, s *subscriber, event any, env envelope) (err error) {
+	if !s.accepts(ctx, event) {
+		return nil
+	}
+	if env.report != nil {
+		defer func(start time.Time) { env.report.record(s, start, err) }(time.Now())
+	}
+	defer b.finalize(ctx, s, event, &err)
+	if s.breaker != nil {
+		return s.breaker.run(ctx, b, s, func() error {
+			return b.attempts(ctx, s, event, env)
+		})
+	}
+	return b.attempts(ctx, s, event, env)
+}
+
+// attempts invokes s until it succeeds or its retry policy gives up.
+func (b *Bus) attempts(ctx context.Context, s *subscriber, event any, env envelope) (err error) {
+	for attempt := 1; ; attempt++ {
+		err = b.invoke(withDelivery(ctx, s, env, attempt, err), s, event)
+		if err == nil || !s.retry.again(ctx, attempt) {
+			return err
+		}
+	}
+}
+
+// invoke runs a single attempt at delivering event to s.
+func (b *Bus) invoke(ctx context.Context, s *subscriber, event any) (err error) {
+	defer b.recoverPanic(ctx, s, &err)
+	defer b.traceHandler(ctx, s)()
+	ctx = b.withBudget(ctx, s)
+
+	b.mu.RLock()
+	ics := b.interceptors
+	b.mu.RUnlock()
+	if len(ics) == 0 {
+		return s.handle(ctx, event)
+	}
+
+	next := DispatchFunc(func(ctx context.Context, event any, _ HandlerInfo) error {
+		return s.handle(ctx, event)
+	})
+	for i := len(ics) - 1; i >= 0; i-- {
+		next = ics[i](next)
+	}
+	return next(ctx, event, s.info())
+}
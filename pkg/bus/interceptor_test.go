@@ -0,0 +1,66 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestInterceptor(t *testing.T) {
+	b := bus.New(bus.WithStrategy(bus.BestEffort))
+	var log []string
+
+	for _, name := range []string{"outer", "inner"} {
+		name := name
+		bus.Use(b, func(next bus.DispatchFunc) bus.DispatchFunc {
+			return func(ctx context.Context, event any, h bus.HandlerInfo) error {
+				log = append(log, fmt.Sprintf("%s>%d", name, h.Priority))
+				err := next(ctx, event, h)
+				log = append(log, fmt.Sprintf("%s<%v", name, err))
+				return err
+			}
+		})
+	}
+
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		log = append(log, "handler")
+		return errors.New("boom")
+	}, bus.PriorityHigh)
+
+	_ = bus.Emit(context.Background(), b, &Event{})
+
+	want := []string{"outer>100", "inner>100", "handler", "inner<boom", "outer<boom"}
+	if !reflect.DeepEqual(log, want) {
+		t.Fatalf("want %v, got %v", want, log)
+	}
+}
+
+func TestInterceptor_ShortCircuit(t *testing.T) {
+	b := bus.New()
+	denied := errors.New("denied")
+	bus.Use(b, func(next bus.DispatchFunc) bus.DispatchFunc {
+		return func(ctx context.Context, event any, h bus.HandlerInfo) error {
+			if h.Type == reflect.TypeFor[*Event]() {
+				return denied
+			}
+			return next(ctx, event, h)
+		}
+	})
+
+	called := false
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		called = true
+		return nil
+	})
+
+	if err := bus.Emit(context.Background(), b, &Event{}); !errors.Is(err, denied) {
+		t.Fatalf("Expected denied, got %v", err)
+	}
+	if called {
+		t.Fatal("Handler called despite interceptor")
+	}
+}
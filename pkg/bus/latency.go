@@ -0,0 +1,104 @@
+package bus
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+const latencySamples = 1024
+
+// Percentiles summarizes a latency distribution.
+type Percentiles struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// LatencyStats reports, for one event type, the time from emission to the
+// start of the first handler and to the completion of the last one,
+// computed over the most recent dispatches.
+type LatencyStats struct {
+	Count      uint64
+	FirstStart Percentiles
+	Completion Percentiles
+}
+
+type ring struct {
+	samples []time.Duration
+	next    int
+}
+
+func (r *ring) add(d time.Duration) {
+	if len(r.samples) < latencySamples {
+		r.samples = append(r.samples, d)
+		return
+	}
+	r.samples[r.next] = d
+	r.next = (r.next + 1) % latencySamples
+}
+
+func (r *ring) percentiles() Percentiles {
+	if len(r.samples) == 0 {
+		return Percentiles{}
+	}
+	sorted := append([]time.Duration(nil), r.samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	at := func(p float64) time.Duration {
+		return sorted[int(p*float64(len(sorted)-1))]
+	}
+	return Percentiles{P50: at(0.50), P90: at(0.90), P99: at(0.99), Max: sorted[len(sorted)-1]}
+}
+
+type typeLatency struct {
+	count      uint64
+	firstStart ring
+	completion ring
+}
+
+type latencies struct {
+	mu     sync.Mutex
+	byType map[reflect.Type]*typeLatency
+}
+
+// WithLatencyTracking records dispatch latencies per event type, exposed by
+// Bus.Latency.
+func WithLatencyTracking() Option {
+	return func(b *Bus) {
+		b.latencies = &latencies{byType: map[reflect.Type]*typeLatency{}}
+	}
+}
+
+func (l *latencies) record(key reflect.Type, firstStart, completion time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	tl := l.byType[key]
+	if tl == nil {
+		tl = &typeLatency{}
+		l.byType[key] = tl
+	}
+	tl.count++
+	tl.firstStart.add(firstStart)
+	tl.completion.add(completion)
+}
+
+// Latency returns the dispatch latency statistics per event type. It is
+// empty unless the bus was created with WithLatencyTracking.
+func (b *Bus) Latency() map[reflect.Type]LatencyStats {
+	out := map[reflect.Type]LatencyStats{}
+	if b.latencies == nil {
+		return out
+	}
+	b.latencies.mu.Lock()
+	defer b.latencies.mu.Unlock()
+	for key, tl := range b.latencies.byType {
+		out[key] = LatencyStats{
+			Count:      tl.count,
+			FirstStart: tl.firstStart.percentiles(),
+			Completion: tl.completion.percentiles(),
+		}
+	}
+	return out
+}
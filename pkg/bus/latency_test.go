@@ -0,0 +1,37 @@
+package bus_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestLatency(t *testing.T) {
+	b := bus.New(bus.WithLatencyTracking())
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		time.Sleep(2 * time.Millisecond)
+		return nil
+	})
+
+	for i := 0; i < 5; i++ {
+		_ = bus.Emit(context.Background(), b, &Event{})
+	}
+
+	stats, ok := b.Latency()[reflect.TypeFor[*Event]()]
+	if !ok || stats.Count != 5 {
+		t.Fatalf("Expected 5 samples, got %+v", stats)
+	}
+	if stats.Completion.P50 < 2*time.Millisecond {
+		t.Fatalf("Expected completion to include handler time, got %s", stats.Completion.P50)
+	}
+	if stats.FirstStart.Max > stats.Completion.P50 {
+		t.Fatalf("Expected first start before completion: %+v", stats)
+	}
+
+	if len(bus.New().Latency()) != 0 {
+		t.Fatal("Expected no latency stats without tracking")
+	}
+}
@@ -0,0 +1,45 @@
+package bus
+
+import (
+	"context"
+	"sync"
+)
+
+// Lazy is a payload loaded on first access. Embedding a *Lazy in an event
+// lets handlers that need the heavy data load it once, shared by all of
+// them, while the others never pay for it.
+type Lazy[T any] struct {
+	load   func(ctx context.Context) (T, error)
+	mu     sync.Mutex
+	value  T
+	loaded bool
+}
+
+// NewLazy returns a Lazy materialized by load.
+func NewLazy[T any](load func(ctx context.Context) (T, error)) *Lazy[T] {
+	return &Lazy[T]{load: load}
+}
+
+// Get returns the payload, loading it on the first call. Failed loads are
+// not cached and are retried by the next call.
+func (l *Lazy[T]) Get(ctx context.Context) (T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.loaded {
+		return l.value, nil
+	}
+	v, err := l.load(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	l.value, l.loaded = v, true
+	return v, nil
+}
+
+// Loaded reports whether the payload has been materialized.
+func (l *Lazy[T]) Loaded() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.loaded
+}
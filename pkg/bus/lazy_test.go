@@ -0,0 +1,51 @@
+package bus_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type Uploaded struct {
+	Name string
+	Blob *bus.Lazy[[]byte]
+}
+
+func TestLazy(t *testing.T) {
+	b := bus.New(bus.WithMaxEventSize(1024, nil))
+	loads := 0
+	blob := bus.NewLazy(func(ctx context.Context) ([]byte, error) {
+		loads++
+		return make([]byte, 1<<20), nil
+	})
+
+	counted := 0
+	bus.Subscribe(b, func(ctx context.Context, e Uploaded) error {
+		counted++
+		return nil
+	})
+
+	if err := bus.Emit(context.Background(), b, Uploaded{Name: "a", Blob: blob}); err != nil {
+		t.Fatalf("Emit failed: %v", err)
+	}
+	if counted != 1 || blob.Loaded() {
+		t.Fatal("Expected the payload to stay unloaded")
+	}
+
+	for i := 0; i < 2; i++ {
+		bus.Subscribe(b, func(ctx context.Context, e Uploaded) error {
+			data, err := e.Blob.Get(ctx)
+			if err != nil || len(data) != 1<<20 {
+				t.Fatalf("Unexpected payload: %d bytes, %v", len(data), err)
+			}
+			return nil
+		})
+	}
+	if err := bus.Emit(context.Background(), b, Uploaded{Name: "b", Blob: blob}); err != nil {
+		t.Fatalf("Emit failed: %v", err)
+	}
+	if loads != 1 {
+		t.Fatalf("Expected 1 load, got %d", loads)
+	}
+}
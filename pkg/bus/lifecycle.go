@@ -0,0 +1,151 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/mirkobrombin/go-foundation/pkg/safemap"
+)
+
+var (
+	ErrNotStarted        = errors.New("bus: not started")
+	ErrStartupBufferFull = errors.New("bus: startup buffer full")
+)
+
+// Lifecycle is implemented by handlers owning resources that must be
+// started before the first delivery and released on shutdown.
+type Lifecycle interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// WithLifecycle attaches l to the subscription, typically the struct whose
+// method is the handler. The bus starts it in Bus.Start and stops it in
+// Bus.Close.
+func WithLifecycle(l Lifecycle) SubscribeOption {
+	return subscribeOption(func(s *subscriber) { s.lifecycle = l })
+}
+
+// Start runs the init hooks of all subscriptions and starts the handlers
+// implementing Lifecycle, in priority order, then the managed components.
+// It stops at the first failure.
+// On success the events buffered during startup are delivered and the bus
+// is marked as running.
+func (b *Bus) Start(ctx context.Context) error {
+	for _, s := range b.ordered() {
+		if err := s.initialize(ctx); err != nil {
+			return err
+		}
+		if s.lifecycle == nil || s.running.Load() {
+			continue
+		}
+		if err := s.lifecycle.Start(ctx); err != nil {
+			return fmt.Errorf("bus: start of %s failed: %w", s.info().Name, err)
+		}
+		s.running.Store(true)
+	}
+	if err := b.startComponents(ctx); err != nil {
+		return err
+	}
+	b.flushPending()
+	return nil
+}
+
+// enqueue holds fn until the bus starts. It reports false when the bus
+// has started in the meantime and fn should run right away.
+func (b *Bus) enqueue(fn func() error) (bool, error) {
+	b.startMu.Lock()
+	defer b.startMu.Unlock()
+	if b.started.Load() {
+		return false, nil
+	}
+	if b.pendingLimit == 0 {
+		return true, ErrNotStarted
+	}
+	if len(b.pending) >= b.pendingLimit {
+		return true, ErrStartupBufferFull
+	}
+	b.pending = append(b.pending, fn)
+	return true, nil
+}
+
+// flushPending delivers the buffered events in order. Events emitted while
+// flushing, including by handlers, are queued behind them, so the bus is
+// only marked as started once the buffer is drained.
+func (b *Bus) flushPending() {
+	for {
+		b.startMu.Lock()
+		pending := b.pending
+		b.pending = nil
+		if len(pending) == 0 {
+			b.started.Store(true)
+			b.startMu.Unlock()
+			return
+		}
+		b.startMu.Unlock()
+
+		for _, fn := range pending {
+			if err := fn(); err != nil {
+				b.asyncError(err)
+			}
+		}
+	}
+}
+
+// stopLifecycles stops the lifecycles started by Start, in reverse order.
+// Those Start did not reach, because the bus was never started or an
+// earlier start failed, are left alone.
+func (b *Bus) stopLifecycles(ctx context.Context) error {
+	subs := b.ordered()
+	var errs []error
+	for i := len(subs) - 1; i >= 0; i-- {
+		s := subs[i]
+		if s.lifecycle == nil || !s.running.CompareAndSwap(true, false) {
+			continue
+		}
+		if err := s.lifecycle.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("bus: stop of %s failed: %w", s.info().Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ordered returns all subscriptions across event types sorted by priority,
+// then by subscription order and dependencies.
+func (b *Bus) ordered() []*subscriber {
+	all := b.registered()
+	_ = arrange(all)
+	return all
+}
+
+// registered returns the subscriptions of every registry of the bus:
+// plain, topic, pattern and wildcard handlers, responders, command
+// handlers and contributors.
+func (b *Bus) registered() []*subscriber {
+	var all []*subscriber
+	b.subscribers.Range(func(_ reflect.Type, subs []*subscriber) bool {
+		all = append(all, subs...)
+		return true
+	})
+	b.topics.Range(func(_ topicKey, subs []*subscriber) bool {
+		all = append(all, subs...)
+		return true
+	})
+	b.gatherers.Range(func(_ gatherKey, subs []*subscriber) bool {
+		all = append(all, subs...)
+		return true
+	})
+	for _, m := range []*safemap.Map[reflect.Type, *subscriber]{b.responders, b.commands} {
+		m.Range(func(_ reflect.Type, s *subscriber) bool {
+			all = append(all, s)
+			return true
+		})
+	}
+	all = append(all, b.patterns.all()...)
+	b.mu.RLock()
+	all = append(all, b.wildcard...)
+	b.mu.RUnlock()
+	return all
+}
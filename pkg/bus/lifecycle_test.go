@@ -0,0 +1,196 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type component struct {
+	name string
+	log  *[]string
+}
+
+func (c *component) Start(ctx context.Context) error {
+	*c.log = append(*c.log, "start "+c.name)
+	return nil
+}
+
+func (c *component) Stop(ctx context.Context) error {
+	*c.log = append(*c.log, "stop "+c.name)
+	return nil
+}
+
+func (c *component) Handle(ctx context.Context, e *Event) error {
+	return nil
+}
+
+func TestLifecycle_Order(t *testing.T) {
+	b := bus.New()
+	var log []string
+
+	low := &component{name: "low", log: &log}
+	high := &component{name: "high", log: &log}
+	other := &component{name: "other", log: &log}
+
+	bus.Subscribe(b, low.Handle, bus.PriorityLow, bus.WithLifecycle(low))
+	bus.Subscribe(b, high.Handle, bus.PriorityHigh, bus.WithLifecycle(high))
+	bus.Subscribe(b, func(ctx context.Context, e InvoiceIssued) error { return nil }, bus.WithLifecycle(other))
+
+	if err := b.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	want := []string{"start high", "start other", "start low", "stop low", "stop other", "stop high"}
+	if !reflect.DeepEqual(log, want) {
+		t.Fatalf("Unexpected lifecycle order:\nwant %v\ngot  %v", want, log)
+	}
+}
+
+func TestLifecycle_AllRegistries(t *testing.T) {
+	b := bus.New()
+	var log []string
+
+	topic := &component{name: "topic", log: &log}
+	pattern := &component{name: "pattern", log: &log}
+	responder := &component{name: "responder", log: &log}
+	bus.SubscribeTopic(b, "orders.eu", topic.Handle, bus.PriorityHigh, bus.WithLifecycle(topic))
+	bus.SubscribeTopic(b, "orders.*", pattern.Handle, bus.WithLifecycle(pattern))
+	bus.Respond(b, func(ctx context.Context, q GetPrice) (float64, error) { return 1, nil },
+		bus.PriorityLow, bus.WithLifecycle(responder))
+
+	if err := b.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	var handlers int
+	for _, tt := range bus.Topology(b) {
+		handlers += len(tt.Handlers)
+	}
+	if handlers != 3 || len(b.Subscriptions()) != 3 {
+		t.Fatalf("Expected all registries in the topology, got %d handlers", handlers)
+	}
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	want := []string{"start topic", "start pattern", "start responder", "stop responder", "stop pattern", "stop topic"}
+	if !reflect.DeepEqual(log, want) {
+		t.Fatalf("Unexpected lifecycle order:\nwant %v\ngot  %v", want, log)
+	}
+}
+
+type brokenComponent struct {
+	component
+}
+
+func (c *brokenComponent) Start(ctx context.Context) error {
+	return errors.New("unavailable")
+}
+
+func TestLifecycle_StopsOnlyStarted(t *testing.T) {
+	var log []string
+	idle := &component{name: "idle", log: &log}
+	b := bus.New()
+	bus.Subscribe(b, idle.Handle, bus.WithLifecycle(idle))
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if len(log) != 0 {
+		t.Fatalf("Expected no lifecycle stopped without Start, got %v", log)
+	}
+
+	b = bus.New()
+	high := &component{name: "high", log: &log}
+	broken := &brokenComponent{component{name: "broken", log: &log}}
+	low := &component{name: "low", log: &log}
+	bus.Subscribe(b, high.Handle, bus.PriorityHigh, bus.WithLifecycle(high))
+	bus.Subscribe(b, broken.Handle, bus.WithLifecycle(broken))
+	bus.Subscribe(b, low.Handle, bus.PriorityLow, bus.WithLifecycle(low))
+
+	if err := b.Start(context.Background()); err == nil {
+		t.Fatal("Expected Start to fail")
+	}
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	want := []string{"start high", "stop high"}
+	if !reflect.DeepEqual(log, want) {
+		t.Fatalf("want %v, got %v", want, log)
+	}
+}
+
+func TestLifecycle_StartErrorName(t *testing.T) {
+	var log []string
+	broken := &brokenComponent{component{name: "broken", log: &log}}
+	b := bus.New()
+	bus.Subscribe(b, broken.Handle, bus.WithLifecycle(broken), bus.WithName("inventory"))
+
+	err := b.Start(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "start of inventory failed") {
+		t.Fatalf("Expected the handler name in the start error, got %v", err)
+	}
+}
+
+func TestLifecycle_ExplicitStart(t *testing.T) {
+	b := bus.New(bus.WithExplicitStart())
+	calls := 0
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		calls++
+		return nil
+	})
+
+	if err := bus.Emit(context.Background(), b, &Event{}); !errors.Is(err, bus.ErrNotStarted) {
+		t.Fatalf("Expected ErrNotStarted, got %v", err)
+	}
+
+	if err := b.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := bus.Emit(context.Background(), b, &Event{}); err != nil {
+		t.Fatalf("Emit failed: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestLifecycle_StartupBuffer(t *testing.T) {
+	b := bus.New(bus.WithStartupBuffer(2))
+	var got []string
+
+	if err := bus.Emit(context.Background(), b, &Event{Greeting: "one"}); err != nil {
+		t.Fatalf("Emit failed: %v", err)
+	}
+
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		got = append(got, e.Greeting)
+		return nil
+	})
+
+	if err := bus.Emit(context.Background(), b, &Event{Greeting: "two"}); err != nil {
+		t.Fatalf("Emit failed: %v", err)
+	}
+	if err := bus.Emit(context.Background(), b, &Event{Greeting: "three"}); !errors.Is(err, bus.ErrStartupBufferFull) {
+		t.Fatalf("Expected ErrStartupBufferFull, got %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatal("Events delivered before Start")
+	}
+
+	if err := b.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	_ = bus.Emit(context.Background(), b, &Event{Greeting: "four"})
+
+	want := []string{"one", "two", "four"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
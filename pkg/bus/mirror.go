@@ -0,0 +1,33 @@
+package bus
+
+import (
+	"context"
+	"reflect"
+)
+
+// Mirror duplicates the events emitted on primary for which filter returns
+// true, or all of them with a nil filter, to shadow, typically a bus wired
+// to staging consumers; a nil bus is the default bus. Events are emitted
+// on shadow asynchronously, keyed by their dynamic type, once the primary
+// handlers have succeeded; the shadow side never slows down or fails the
+// primary path. Cancel the returned subscription to stop mirroring.
+func Mirror(primary, shadow *Bus, filter func(event any) bool) *Subscription {
+	if primary == nil {
+		primary = defaultBus
+	}
+	if shadow == nil {
+		shadow = defaultBus
+	}
+	id := forwardRules.Add(1)
+	return SubscribeAll(primary, func(ctx context.Context, event any) error {
+		if filter != nil && !filter(event) {
+			return nil
+		}
+		ctx, ok := forward(context.WithoutCancel(ctx), id)
+		if !ok {
+			return nil
+		}
+		emitAsync(ctx, shadow, newEnvelope(reflect.TypeOf(event), event))
+		return nil
+	}, WithName("bus.Mirror"))
+}
@@ -0,0 +1,75 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestMirror(t *testing.T) {
+	primary, shadow := bus.New(), bus.New()
+	var mu sync.Mutex
+	var mirrored []int
+	bus.Subscribe(shadow, func(ctx context.Context, e OrderCreated) error {
+		mu.Lock()
+		defer mu.Unlock()
+		mirrored = append(mirrored, e.ID)
+		return errors.New("staging is broken")
+	})
+	bus.Subscribe(primary, func(ctx context.Context, e OrderCreated) error { return nil })
+
+	sub := bus.Mirror(primary, shadow, func(event any) bool {
+		e, ok := event.(OrderCreated)
+		return ok && e.ID > 0
+	})
+
+	ctx := context.Background()
+	for _, id := range []int{0, 1, 2} {
+		if err := bus.Emit(ctx, primary, OrderCreated{ID: id}); err != nil {
+			t.Fatalf("Shadow failure leaked into primary: %v", err)
+		}
+	}
+	sub.Cancel()
+	_ = bus.Emit(ctx, primary, OrderCreated{ID: 3})
+
+	closeCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_ = shadow.Close(closeCtx)
+	_ = primary.Close(closeCtx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(mirrored) != 2 {
+		t.Fatalf("Expected 2 mirrored events, got %v", mirrored)
+	}
+}
+
+func TestMirror_DefaultShadow(t *testing.T) {
+	type shadowed struct{ ID int }
+	primary := bus.New()
+	got := make(chan int, 1)
+	shadowSub := bus.Subscribe(nil, func(ctx context.Context, e shadowed) error {
+		got <- e.ID
+		return nil
+	})
+	defer shadowSub.Cancel()
+
+	sub := bus.Mirror(primary, nil, nil)
+	defer sub.Cancel()
+	if err := bus.Emit(context.Background(), primary, shadowed{ID: 7}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	select {
+	case id := <-got:
+		if id != 7 {
+			t.Fatalf("Expected event 7 on the default bus, got %d", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Event was not mirrored to the default bus")
+	}
+}
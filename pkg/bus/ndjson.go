@@ -0,0 +1,98 @@
+package bus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/mirkobrombin/go-foundation/pkg/options"
+)
+
+var ErrUnknownType = errors.New("bus: unknown event type")
+
+// TypeResolver maps the type name found in a dump to a Go event type.
+type TypeResolver func(name string) (reflect.Type, bool)
+
+// ResolveTypes returns a TypeResolver backed by types.
+func ResolveTypes(types map[string]reflect.Type) TypeResolver {
+	return func(name string) (reflect.Type, bool) {
+		t, ok := types[name]
+		return t, ok
+	}
+}
+
+type importConfig struct {
+	interval time.Duration
+}
+
+type ImportOption = options.Option[importConfig]
+
+// WithImportRate limits the import to perSecond events per second.
+func WithImportRate(perSecond int) ImportOption {
+	return func(c *importConfig) {
+		if perSecond > 0 {
+			c.interval = time.Second / time.Duration(perSecond)
+		}
+	}
+}
+
+type ndjsonRecord struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// ImportNDJSON reads newline-delimited records of the form
+// {"type": "<name>", "data": {...}}, such as wire envelopes, from r,
+// decodes each payload into the type returned by resolve and emits it on
+// b. It stops at the first decoding or dispatch error and returns the
+// number of emitted events.
+func ImportNDJSON(ctx context.Context, b *Bus, r io.Reader, resolve TypeResolver, opts ...ImportOption) (int, error) {
+	if b == nil {
+		b = defaultBus
+	}
+	cfg := &importConfig{}
+	options.Apply(cfg, opts...)
+
+	var tick <-chan time.Time
+	if cfg.interval > 0 {
+		ticker := time.NewTicker(cfg.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	dec := json.NewDecoder(r)
+	n := 0
+	for {
+		var rec ndjsonRecord
+		if err := dec.Decode(&rec); err == io.EOF {
+			return n, nil
+		} else if err != nil {
+			return n, fmt.Errorf("bus: record %d: %w", n+1, err)
+		}
+
+		typ, ok := resolve(rec.Type)
+		if !ok {
+			return n, fmt.Errorf("bus: record %d: %w: %q", n+1, ErrUnknownType, rec.Type)
+		}
+		v := reflect.New(typ)
+		if err := json.Unmarshal(rec.Data, v.Interface()); err != nil {
+			return n, fmt.Errorf("bus: record %d: %w", n+1, err)
+		}
+
+		if tick != nil && n > 0 {
+			select {
+			case <-tick:
+			case <-ctx.Done():
+				return n, ctx.Err()
+			}
+		}
+		if err := b.send(ctx, newEnvelope(typ, v.Elem().Interface())); err != nil {
+			return n, fmt.Errorf("bus: record %d: %w", n+1, err)
+		}
+		n++
+	}
+}
@@ -0,0 +1,63 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestImportNDJSON(t *testing.T) {
+	b := bus.New()
+	var orders []int
+	var invoices []int
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		orders = append(orders, e.ID)
+		return nil
+	})
+	bus.Subscribe(b, func(ctx context.Context, e InvoiceIssued) error {
+		invoices = append(invoices, e.ID)
+		return nil
+	})
+
+	resolve := bus.ResolveTypes(map[string]reflect.Type{
+		"order.created":  reflect.TypeFor[OrderCreated](),
+		"invoice.issued": reflect.TypeFor[InvoiceIssued](),
+	})
+
+	dump := `{"type":"order.created","data":{"ID":1}}
+{"type":"invoice.issued","data":{"ID":10}}
+{"type":"order.created","data":{"ID":2}}
+`
+	n, err := bus.ImportNDJSON(context.Background(), b, strings.NewReader(dump), resolve, bus.WithImportRate(1000))
+	if err != nil || n != 3 {
+		t.Fatalf("Expected 3 imported events, got %d, %v", n, err)
+	}
+	if !reflect.DeepEqual(orders, []int{1, 2}) || !reflect.DeepEqual(invoices, []int{10}) {
+		t.Fatalf("Unexpected deliveries: orders=%v invoices=%v", orders, invoices)
+	}
+
+	n, err = bus.ImportNDJSON(context.Background(), b, strings.NewReader(`{"type":"nope","data":{}}`), resolve)
+	if n != 0 || !errors.Is(err, bus.ErrUnknownType) {
+		t.Fatalf("Expected ErrUnknownType, got %d, %v", n, err)
+	}
+}
+
+func TestImportNDJSON_Closed(t *testing.T) {
+	b := bus.New()
+	called := false
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		called = true
+		return nil
+	})
+	_ = b.Close(context.Background())
+
+	resolve := bus.ResolveTypes(map[string]reflect.Type{"order.created": reflect.TypeFor[OrderCreated]()})
+	n, err := bus.ImportNDJSON(context.Background(), b, strings.NewReader(`{"type":"order.created","data":{"ID":1}}`), resolve)
+	if !errors.Is(err, bus.ErrClosed) || n != 0 || called {
+		t.Fatalf("Expected ErrClosed, got %d, %v (called=%v)", n, err, called)
+	}
+}
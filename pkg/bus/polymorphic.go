@@ -0,0 +1,84 @@
+package bus
+
+import (
+	"reflect"
+	"sync/atomic"
+
+	"github.com/mirkobrombin/go-foundation/pkg/safemap"
+)
+
+type assignability struct {
+	gen    uint64
+	ifaces []reflect.Type
+}
+
+type polymorphism struct {
+	gen   atomic.Uint64
+	index *safemap.Map[reflect.Type, assignability]
+}
+
+// WithPolymorphicDispatch also delivers events to the subscribers of the
+// interfaces their type implements, so emitting *Event reaches handlers of
+// IEvent. Handlers of all matching types run together in priority order.
+// The interfaces implemented by each emitted type are computed once and
+// cached until a new interface subscription is made.
+func WithPolymorphicDispatch() Option {
+	return func(b *Bus) {
+		b.poly = &polymorphism{index: safemap.New[reflect.Type, assignability]()}
+	}
+}
+
+// invalidate drops the cached index when key is an interface.
+func (p *polymorphism) invalidate(key reflect.Type) {
+	if p != nil && key.Kind() == reflect.Interface {
+		p.gen.Add(1)
+	}
+}
+
+func (b *Bus) interfacesOf(key reflect.Type) []reflect.Type {
+	gen := b.poly.gen.Load()
+	if a, ok := b.poly.index.Get(key); ok && a.gen == gen {
+		return a.ifaces
+	}
+	var ifaces []reflect.Type
+	b.subscribers.Range(func(t reflect.Type, _ []*subscriber) bool {
+		if t != key && t.Kind() == reflect.Interface && key.Implements(t) {
+			ifaces = append(ifaces, t)
+		}
+		return true
+	})
+	b.poly.index.Set(key, assignability{gen: gen, ifaces: ifaces})
+	return ifaces
+}
+
+// subscribersOf returns the handlers a dispatch of key on topic runs.
+func (b *Bus) subscribersOf(key reflect.Type, topic string) ([]*subscriber, bool) {
+	if topic != "" {
+		subs, _ := b.topics.Get(topicKey{key, topic})
+		if matched := b.patterns.match(key, topic); len(matched) > 0 {
+			subs = merge(append(matched, subs)...)
+		}
+		return subs, len(subs) > 0
+	}
+	subs := b.handlersOf(key)
+	return subs, len(subs) > 0
+}
+
+// resolve computes the handlers of events of type key emitted without a
+// topic.
+func (b *Bus) resolve(key reflect.Type) []*subscriber {
+	subs, _ := b.subscribers.Get(key)
+	if b.poly == nil {
+		return subs
+	}
+	ifaces := b.interfacesOf(key)
+	if len(ifaces) == 0 {
+		return subs
+	}
+	lists := [][]*subscriber{subs}
+	for _, t := range ifaces {
+		more, _ := b.subscribers.Get(t)
+		lists = append(lists, more)
+	}
+	return merge(lists...)
+}
@@ -0,0 +1,50 @@
+package bus_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestPolymorphicDispatch(t *testing.T) {
+	b := bus.New(bus.WithPolymorphicDispatch())
+	var calls []string
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		calls = append(calls, "concrete")
+		return nil
+	})
+	bus.Subscribe(b, func(ctx context.Context, e IEvent) error {
+		calls = append(calls, "interface:"+e.GetGreeting())
+		return nil
+	}, bus.PriorityHigh)
+
+	_ = bus.Emit(context.Background(), b, &Event{Greeting: "hi"})
+	if len(calls) != 2 || calls[0] != "interface:hi" || calls[1] != "concrete" {
+		t.Fatalf("Unexpected calls: %v", calls)
+	}
+
+	// The cached index is refreshed by later interface subscriptions.
+	bus.Subscribe(b, func(ctx context.Context, e interface{ GetGreeting() string }) error {
+		calls = append(calls, "anonymous")
+		return nil
+	}, bus.PriorityLow)
+	calls = nil
+	_ = bus.Emit(context.Background(), b, &Event{Greeting: "hi"})
+	if len(calls) != 3 || calls[2] != "anonymous" {
+		t.Fatalf("Unexpected calls after new subscription: %v", calls)
+	}
+}
+
+func TestPolymorphicDispatch_Disabled(t *testing.T) {
+	b := bus.New()
+	called := false
+	bus.Subscribe(b, func(ctx context.Context, e IEvent) error {
+		called = true
+		return nil
+	})
+	_ = bus.Emit(context.Background(), b, &Event{})
+	if called {
+		t.Fatal("Expected interface handler not to be reached by default")
+	}
+}
@@ -0,0 +1,103 @@
+package bus
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrQueueFull is the outcome of an asynchronous emission rejected or
+// dropped because the worker pool queue was full.
+var ErrQueueFull = errors.New("bus: async queue full")
+
+// OverflowPolicy decides what EmitAsync does when the worker pool queue
+// is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for room in the queue.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest queued event to make room.
+	OverflowDropOldest
+	// OverflowDropNewest discards the event being emitted.
+	OverflowDropNewest
+	// OverflowReject discards the event being emitted and reports
+	// ErrQueueFull to the async error callback.
+	OverflowReject
+)
+
+type job struct {
+	key reflect.Type
+	// run runs the job, held telling whether it already holds the
+	// bulkhead slot of key.
+	run    func(held bool)
+	reject func(err error, report bool)
+}
+
+// WithWorkerPool runs EmitAsync dispatches on n workers fed by a queue of
+// queueSize events instead of a goroutine per event. By default EmitAsync
+// blocks while the queue is full; see WithOverflow. The pool takes
+// precedence over WithGroup.
+func WithWorkerPool(n, queueSize int) Option {
+	return func(b *Bus) {
+		b.workers = n
+		b.queue = make(chan *job, queueSize)
+	}
+}
+
+// WithOverflow sets the policy applied when the worker pool queue is full.
+// Discarded events resolve their Future with ErrQueueFull.
+func WithOverflow(p OverflowPolicy) Option {
+	return func(b *Bus) { b.overflow = p }
+}
+
+func (b *Bus) startWorkers() {
+	for range b.workers {
+		b.background.Add(1)
+		go func() {
+			defer b.background.Done()
+			for {
+				select {
+				case j := <-b.queue:
+					b.runJob(j)
+				case <-b.done:
+					return
+				}
+			}
+		}()
+	}
+}
+
+// submit hands j to the pool, reporting false once the bus is closed.
+func (b *Bus) submit(j *job) bool {
+	if b.overflow == OverflowBlock {
+		select {
+		case b.queue <- j:
+			return true
+		case <-b.done:
+			return false
+		}
+	}
+	for {
+		select {
+		case b.queue <- j:
+			return true
+		case <-b.done:
+			return false
+		default:
+		}
+		switch b.overflow {
+		case OverflowDropOldest:
+			select {
+			case old := <-b.queue:
+				old.reject(ErrQueueFull, false)
+			default:
+			}
+		case OverflowReject:
+			j.reject(ErrQueueFull, true)
+			return true
+		default:
+			j.reject(ErrQueueFull, false)
+			return true
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestWorkerPool(t *testing.T) {
+	b := bus.New(bus.WithWorkerPool(2, 100))
+	var running, peak, total atomic.Int32
+	bus.Subscribe(b, func(ctx context.Context, e Tick) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		total.Add(1)
+		return nil
+	})
+
+	base := runtime.NumGoroutine()
+	for i := range 50 {
+		bus.EmitAsync(context.Background(), b, Tick{Seq: i})
+	}
+	if g := runtime.NumGoroutine(); g > base+5 {
+		t.Fatalf("Expected bounded goroutines, got %d over %d", g, base)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := b.Close(ctx); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if total.Load() != 50 {
+		t.Fatalf("Expected 50 deliveries, got %d", total.Load())
+	}
+	if peak.Load() > 2 {
+		t.Fatalf("Expected at most 2 concurrent handlers, got %d", peak.Load())
+	}
+}
+
+func TestWorkerPool_Overflow(t *testing.T) {
+	tests := []struct {
+		policy   bus.OverflowPolicy
+		rejected []int
+		reported int
+	}{
+		{bus.OverflowDropOldest, []int{1}, 0},
+		{bus.OverflowDropNewest, []int{3}, 0},
+		{bus.OverflowReject, []int{3}, 1},
+	}
+	for _, tt := range tests {
+		var reported atomic.Int32
+		b := bus.New(
+			bus.WithWorkerPool(1, 2),
+			bus.WithOverflow(tt.policy),
+			bus.WithOnAsyncError(func(err error) {
+				if errors.Is(err, bus.ErrQueueFull) {
+					reported.Add(1)
+				}
+			}),
+		)
+		started, release := make(chan struct{}), make(chan struct{})
+		bus.Subscribe(b, func(ctx context.Context, e Tick) error {
+			if e.Seq == 0 {
+				close(started)
+				<-release
+			}
+			return nil
+		})
+
+		ctx := context.Background()
+		futures := []*bus.Future{bus.EmitFuture(ctx, b, Tick{Seq: 0})}
+		<-started
+		for i := 1; i <= 3; i++ {
+			futures = append(futures, bus.EmitFuture(ctx, b, Tick{Seq: i}))
+		}
+		close(release)
+
+		var rejected []int
+		for i, f := range futures {
+			if errors.Is(f.Wait(), bus.ErrQueueFull) {
+				rejected = append(rejected, i)
+			}
+		}
+		if !slices.Equal(rejected, tt.rejected) || int(reported.Load()) != tt.reported {
+			t.Errorf("policy %d: rejected %v, reported %d", tt.policy, rejected, reported.Load())
+		}
+		_ = b.Close(ctx)
+	}
+}
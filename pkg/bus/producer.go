@@ -0,0 +1,19 @@
+package bus
+
+import "reflect"
+
+// DeclareProducer records name as the owner of the event type T.
+func DeclareProducer[T any](b *Bus, name string) {
+	if b == nil {
+		b = defaultBus
+	}
+	b.producers.Set(reflect.TypeFor[T](), name)
+}
+
+// ProducerOf returns the declared owner of the event type T, if any.
+func ProducerOf[T any](b *Bus) (string, bool) {
+	if b == nil {
+		b = defaultBus
+	}
+	return b.producers.Get(reflect.TypeFor[T]())
+}
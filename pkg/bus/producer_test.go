@@ -0,0 +1,50 @@
+package bus_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type InvoiceIssued struct {
+	ID int
+}
+
+func TestProducer_Declare(t *testing.T) {
+	b := bus.New()
+
+	if _, ok := bus.ProducerOf[InvoiceIssued](b); ok {
+		t.Fatal("Expected no producer before declaration")
+	}
+
+	bus.DeclareProducer[InvoiceIssued](b, "billing-service")
+
+	name, ok := bus.ProducerOf[InvoiceIssued](b)
+	if !ok || name != "billing-service" {
+		t.Fatalf("Expected billing-service, got %q", name)
+	}
+}
+
+func TestProducer_StrictWarnsOnUnknown(t *testing.T) {
+	var buf bytes.Buffer
+	b := bus.New(
+		bus.WithStrict(),
+		bus.WithLogger(slog.New(slog.NewTextHandler(&buf, nil))),
+	)
+
+	_ = bus.Emit(context.Background(), b, InvoiceIssued{ID: 1})
+	if !strings.Contains(buf.String(), "without a declared producer") {
+		t.Fatalf("Expected warning, got %q", buf.String())
+	}
+
+	buf.Reset()
+	bus.DeclareProducer[InvoiceIssued](b, "billing-service")
+	_ = bus.Emit(context.Background(), b, InvoiceIssued{ID: 2})
+	if buf.Len() != 0 {
+		t.Fatalf("Expected no warning, got %q", buf.String())
+	}
+}
@@ -0,0 +1,27 @@
+package bus
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sync/atomic"
+)
+
+// Origin identifies an emitted event.
+type Origin struct {
+	// ID is unique to the emission within the process.
+	ID   uint64
+	Type reflect.Type
+}
+
+var eventIDs atomic.Uint64
+
+// provenance returns the causation chain of an event emitted with ctx:
+// the event being handled in ctx, preceded by its own ancestors.
+func provenance(ctx context.Context) []Origin {
+	d, ok := DeliveryFrom(ctx)
+	if !ok {
+		return nil
+	}
+	return append(slices.Clip(d.Provenance), d.Event)
+}
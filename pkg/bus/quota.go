@@ -0,0 +1,168 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrQuotaExceeded = errors.New("bus: producer quota exceeded")
+
+// AnyProducer applies a quota to every producer without a specific one.
+const AnyProducer = "*"
+
+// QuotaExceeded is emitted on the bus, once per window, when a producer
+// or a tenant goes over its quota.
+type QuotaExceeded struct {
+	Producer string
+	Tenant   string
+	Type     reflect.Type
+	Limit    int
+	Window   time.Duration
+}
+
+type producerKey struct{}
+
+// ContextWithProducer attributes emissions made with ctx to producer.
+func ContextWithProducer(ctx context.Context, producer string) context.Context {
+	return context.WithValue(ctx, producerKey{}, producer)
+}
+
+type quota struct {
+	limit  int
+	window time.Duration
+}
+
+type usage struct {
+	start time.Time
+	count int
+}
+
+type quotas struct {
+	mu     sync.Mutex
+	limits map[string]quota
+	usage  map[string]*usage
+	totals map[string]uint64
+}
+
+// WithProducerQuota limits producer to limit emissions per window; use
+// AnyProducer for a default quota. Emissions over the quota fail with
+// ErrQuotaExceeded, and a QuotaExceeded event is emitted for the first of
+// them in each window.
+//
+// Producers are identified by ContextWithProducer, then by the owner
+// declared with DeclareProducer, then by the package calling Emit.
+func WithProducerQuota(producer string, limit int, window time.Duration) Option {
+	return func(b *Bus) {
+		if b.quotas == nil {
+			b.quotas = &quotas{
+				limits: map[string]quota{},
+				usage:  map[string]*usage{},
+				totals: map[string]uint64{},
+			}
+		}
+		b.quotas.limits[producer] = quota{limit: limit, window: window}
+	}
+}
+
+// ProducerStats returns the number of emissions per producer. Emissions
+// are only accounted when producer quotas are configured.
+func (b *Bus) ProducerStats() map[string]uint64 {
+	out := map[string]uint64{}
+	if b.quotas == nil {
+		return out
+	}
+	b.quotas.mu.Lock()
+	defer b.quotas.mu.Unlock()
+	for k, v := range b.quotas.totals {
+		out[k] = v
+	}
+	return out
+}
+
+func (b *Bus) checkQuota(ctx context.Context, env envelope) error {
+	if b.quotas == nil {
+		return nil
+	}
+	key, producer := env.key, env.producer
+	if producer == "" {
+		producer = b.producerOf(ctx, key)
+	}
+
+	q := b.quotas
+	q.mu.Lock()
+	q.totals[producer]++
+	limit, ok := q.limits[producer]
+	if !ok {
+		limit, ok = q.limits[AnyProducer]
+	}
+	if !ok {
+		q.mu.Unlock()
+		return nil
+	}
+	now := time.Now()
+	u := q.usage[producer]
+	if u == nil || now.Sub(u.start) >= limit.window {
+		u = &usage{start: now}
+		q.usage[producer] = u
+	}
+	u.count++
+	over, breach := u.count > limit.limit, u.count == limit.limit+1
+	q.mu.Unlock()
+
+	if !over {
+		return nil
+	}
+	if breach {
+		_ = dispatch(ctx, b, newEnvelope(reflect.TypeFor[QuotaExceeded](), QuotaExceeded{
+			Producer: producer,
+			Type:     key,
+			Limit:    limit.limit,
+			Window:   limit.window,
+		}))
+	}
+	return fmt.Errorf("%w: %s emitted more than %d events in %s", ErrQuotaExceeded, producer, limit.limit, limit.window)
+}
+
+func (b *Bus) producerOf(ctx context.Context, key reflect.Type) string {
+	if p, ok := ctx.Value(producerKey{}).(string); ok {
+		return p
+	}
+	if p, ok := b.producers.Get(key); ok {
+		return p
+	}
+	return callerPackage()
+}
+
+// callerPackage returns the import path of the first caller outside the
+// bus package and the signal package wrapping it.
+func callerPackage() string {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	self := reflect.TypeFor[Bus]().PkgPath()
+	wrapper := path.Join(path.Dir(self), "signal")
+	for {
+		f, more := frames.Next()
+		if pkg := packageOf(f.Function); pkg != self && pkg != wrapper && pkg != "" {
+			return pkg
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+func packageOf(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return function
+}
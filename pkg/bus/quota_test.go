@@ -0,0 +1,63 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestProducerQuota(t *testing.T) {
+	b := bus.New(
+		bus.WithProducerQuota("chatty", 2, time.Minute),
+		bus.WithProducerQuota(bus.AnyProducer, 100, time.Minute),
+	)
+	var breaches []bus.QuotaExceeded
+	bus.Subscribe(b, func(ctx context.Context, e bus.QuotaExceeded) error {
+		breaches = append(breaches, e)
+		return nil
+	})
+
+	ctx := bus.ContextWithProducer(context.Background(), "chatty")
+	for i := 0; i < 2; i++ {
+		if err := bus.Emit(ctx, b, &Event{}); err != nil {
+			t.Fatalf("Emit %d failed: %v", i, err)
+		}
+	}
+	for range 2 {
+		if err := bus.Emit(ctx, b, &Event{}); !errors.Is(err, bus.ErrQuotaExceeded) {
+			t.Fatalf("Expected ErrQuotaExceeded, got %v", err)
+		}
+	}
+	if len(breaches) != 1 || breaches[0].Producer != "chatty" {
+		t.Fatalf("Unexpected breaches: %+v", breaches)
+	}
+
+	bus.DeclareProducer[InvoiceIssued](b, "billing")
+	_ = bus.Emit(context.Background(), b, InvoiceIssued{})
+	_ = bus.Emit(context.Background(), b, OrderCreated{})
+
+	stats := b.ProducerStats()
+	if stats["chatty"] != 4 || stats["billing"] != 1 {
+		t.Fatalf("Unexpected stats: %v", stats)
+	}
+	if stats["github.com/mirkobrombin/go-signal/v2/pkg/bus_test"] != 1 {
+		t.Fatalf("Expected inferred caller package in stats: %v", stats)
+	}
+}
+
+func TestProducerQuota_AsyncCaller(t *testing.T) {
+	b := bus.New(bus.WithProducerQuota(bus.AnyProducer, 100, time.Minute), bus.WithWorkerPool(1, 4))
+	bus.EmitAsync(context.Background(), b, OrderCreated{})
+	_ = bus.EmitFuture(context.Background(), b, OrderCreated{})
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	stats := b.ProducerStats()
+	if len(stats) != 1 || stats["github.com/mirkobrombin/go-signal/v2/pkg/bus_test"] != 2 {
+		t.Fatalf("Expected async emissions charged to the caller, got %v", stats)
+	}
+}
@@ -0,0 +1,57 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// RecoveryPolicy selects what happens when a handler panics.
+type RecoveryPolicy int
+
+const (
+	// Repanic lets the panic propagate, as if the bus was not involved.
+	Repanic RecoveryPolicy = iota
+	// RecoverAsError turns the panic into a *PanicError returned as the
+	// handler's error.
+	RecoverAsError
+	// RecoverAndLog is like RecoverAsError and also logs the panic with
+	// its stack trace.
+	RecoverAndLog
+)
+
+// PanicError is the error reported for a recovered handler panic.
+type PanicError struct {
+	Handler HandlerInfo
+	Value   any
+	Stack   []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("bus: handler %s panicked: %v", e.Handler.Name, e.Value)
+}
+
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+func WithRecovery(p RecoveryPolicy) Option {
+	return func(b *Bus) { b.recovery = p }
+}
+
+func (b *Bus) recoverPanic(ctx context.Context, s *subscriber, err *error) {
+	if b.recovery == Repanic {
+		return
+	}
+	v := recover()
+	if v == nil {
+		return
+	}
+	pe := &PanicError{Handler: s.info(), Value: v, Stack: debug.Stack()}
+	if b.recovery == RecoverAndLog {
+		b.logger.ErrorContext(ctx, "bus: handler panicked",
+			"type", fmt.Sprint(s.key), "handler", pe.Handler.Name, "panic", fmt.Sprint(v), "stack", string(pe.Stack))
+	}
+	*err = pe
+}
@@ -0,0 +1,67 @@
+package bus_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestRecovery_AsError(t *testing.T) {
+	b := bus.New(bus.WithRecovery(bus.RecoverAsError), bus.WithStrategy(bus.BestEffort))
+	after := false
+
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		panic("kaboom")
+	}, bus.PriorityHigh)
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		after = true
+		return nil
+	})
+
+	err := bus.Emit(context.Background(), b, &Event{})
+	var pe *bus.PanicError
+	if !errors.As(err, &pe) || pe.Value != "kaboom" || len(pe.Stack) == 0 {
+		t.Fatalf("Expected PanicError, got %v", err)
+	}
+	if !after {
+		t.Fatal("Expected the next handler to run")
+	}
+}
+
+func TestRecovery_Log(t *testing.T) {
+	var buf bytes.Buffer
+	b := bus.New(
+		bus.WithRecovery(bus.RecoverAndLog),
+		bus.WithLogger(slog.New(slog.NewTextHandler(&buf, nil))),
+	)
+	boom := errors.New("boom")
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		panic(boom)
+	})
+
+	if err := bus.Emit(context.Background(), b, &Event{}); !errors.Is(err, boom) {
+		t.Fatalf("Expected wrapped boom, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "handler panicked") {
+		t.Fatalf("Expected panic to be logged, got %q", buf.String())
+	}
+}
+
+func TestRecovery_Repanic(t *testing.T) {
+	b := bus.New()
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		panic("kaboom")
+	})
+
+	defer func() {
+		if recover() != "kaboom" {
+			t.Fatal("Expected the panic to propagate")
+		}
+	}()
+	_ = bus.Emit(context.Background(), b, &Event{})
+}
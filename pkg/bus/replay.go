@@ -0,0 +1,53 @@
+package bus
+
+import (
+	"reflect"
+	"sync"
+)
+
+// replayRing holds the most recent events of a type.
+type replayRing struct {
+	mu     sync.Mutex
+	events []retained
+	next   int
+	full   bool
+}
+
+func (r *replayRing) add(event retained) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events[r.next] = event
+	r.next = (r.next + 1) % len(r.events)
+	r.full = r.full || r.next == 0
+}
+
+// snapshot returns the retained events, oldest first.
+func (r *replayRing) snapshot() []retained {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return append([]retained(nil), r.events[:r.next]...)
+	}
+	return append(append([]retained(nil), r.events[r.next:]...), r.events[:r.next]...)
+}
+
+// WithReplayBuffer retains the last n events of T, which subscriptions
+// made with WithReplay receive before live events.
+func WithReplayBuffer[T any](n int) Option {
+	return func(b *Bus) {
+		if n <= 0 {
+			return
+		}
+		if b.replays == nil {
+			b.replays = map[reflect.Type]*replayRing{}
+		}
+		b.replays[reflect.TypeFor[T]()] = &replayRing{events: make([]retained, n)}
+	}
+}
+
+// WithReplay delivers the events retained by WithReplayBuffer to the
+// handler once it subscribes, oldest first and before any live event,
+// instead of the sticky value of its type.
+func WithReplay() SubscribeOption {
+	return subscribeOption(func(s *subscriber) { s.replay = true })
+}
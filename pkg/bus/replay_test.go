@@ -0,0 +1,36 @@
+package bus_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestReplayBuffer(t *testing.T) {
+	b := bus.New(bus.WithReplayBuffer[Tick](3))
+	ctx := context.Background()
+	for i := range 5 {
+		_ = bus.Emit(ctx, b, Tick{Seq: i})
+	}
+
+	var replayed, live []int
+	bus.Subscribe(b, func(ctx context.Context, e Tick) error {
+		replayed = append(replayed, e.Seq)
+		return nil
+	}, bus.WithReplay())
+	bus.Subscribe(b, func(ctx context.Context, e Tick) error {
+		live = append(live, e.Seq)
+		return nil
+	})
+	_ = bus.Emit(ctx, b, Tick{Seq: 5})
+	_ = b.Close(ctx)
+
+	if !slices.Equal(replayed, []int{2, 3, 4, 5}) {
+		t.Fatalf("Unexpected replayed events: %v", replayed)
+	}
+	if !slices.Equal(live, []int{5}) {
+		t.Fatalf("Expected only live events without WithReplay, got %v", live)
+	}
+}
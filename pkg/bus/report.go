@@ -0,0 +1,58 @@
+package bus
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Report describes the handlers an emission invoked, in completion order.
+type Report struct {
+	Handlers []HandlerReport
+}
+
+// HandlerReport describes the invocation of a handler, retries included.
+type HandlerReport struct {
+	HandlerInfo
+	Duration time.Duration
+	Err      error
+}
+
+// Failed returns the reports of the handlers that failed.
+func (r Report) Failed() []HandlerReport {
+	var out []HandlerReport
+	for _, h := range r.Handlers {
+		if h.Err != nil {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+type reporter struct {
+	mu     sync.Mutex
+	report Report
+}
+
+func (r *reporter) record(s *subscriber, start time.Time, err error) {
+	h := HandlerReport{HandlerInfo: s.info(), Duration: time.Since(start), Err: err}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.Handlers = append(r.report.Handlers, h)
+}
+
+// EmitReport is like Emit and also reports which handlers ran, for how
+// long and with which outcome. Handlers skipped by their filters or by the
+// dispatch strategy are not listed.
+func EmitReport[T any](ctx context.Context, b *Bus, event T) (Report, error) {
+	if b == nil {
+		b = defaultBus
+	}
+	env := newEnvelope(reflect.TypeFor[T](), event)
+	env.report = &reporter{}
+	err := b.send(ctx, env)
+	env.report.mu.Lock()
+	defer env.report.mu.Unlock()
+	return env.report.report, err
+}
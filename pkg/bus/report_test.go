@@ -0,0 +1,36 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestEmitReport(t *testing.T) {
+	b := bus.New(bus.WithStrategy(bus.BestEffort))
+	boom := errors.New("boom")
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error { return nil }, bus.WithName("audit"))
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error { return boom }, bus.WithName("mailer"))
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error { return nil },
+		bus.WithFilter(func(e *Event) bool { return false }))
+
+	report, err := bus.EmitReport(context.Background(), b, &Event{})
+	if !errors.Is(err, boom) {
+		t.Fatalf("Expected boom, got %v", err)
+	}
+	if len(report.Handlers) != 2 {
+		t.Fatalf("Expected 2 invoked handlers, got %+v", report.Handlers)
+	}
+	failed := report.Failed()
+	if len(failed) != 1 || failed[0].Name != "mailer" || !errors.Is(failed[0].Err, boom) {
+		t.Fatalf("Unexpected failures: %+v", failed)
+	}
+
+	bus.SubscribeAll(b, func(ctx context.Context, e any) error { return nil }, bus.WithName("tap"))
+	report, err = bus.EmitReport(context.Background(), b, Tick{})
+	if err != nil || len(report.Handlers) != 1 || report.Handlers[0].Name != "tap" {
+		t.Fatalf("Expected wildcard handler reported, got %+v, %v", report.Handlers, err)
+	}
+}
@@ -0,0 +1,172 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/mirkobrombin/go-foundation/pkg/safemap"
+)
+
+var (
+	// ErrNoResponder is returned by Request when no responder answers the
+	// query type.
+	ErrNoResponder = errors.New("bus: no responder")
+	// ErrResponderExists is reported by Subscription.Err when a query type
+	// already has a responder.
+	ErrResponderExists = errors.New("bus: responder already registered")
+)
+
+type replyKey struct{}
+
+// reply is where a responder stores its answer. Each delivery attempt
+// answers into its own slot, so an attempt abandoned on timeout that
+// answers late cannot overwrite the answer of the attempt that succeeded.
+type reply struct {
+	mu      sync.Mutex
+	answers map[int]any
+}
+
+func (r *reply) set(attempt int, answer any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.answers == nil {
+		r.answers = map[int]any{}
+	}
+	r.answers[attempt] = answer
+}
+
+// last returns the answer of the latest attempt that answered.
+func (r *reply) last() (any, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	latest := 0
+	for attempt := range r.answers {
+		latest = max(latest, attempt)
+	}
+	answer, ok := r.answers[latest]
+	return answer, ok
+}
+
+// Respond registers fn as the responder for queries of type Q. A query
+// type has at most one responder; further registrations are reported by
+// Subscription.Err. Subscribe options apply to the responder as they do
+// to handlers.
+func Respond[Q, R any](b *Bus, fn func(ctx context.Context, query Q) (R, error), opts ...SubscribeOption) *Subscription {
+	if b == nil {
+		b = defaultBus
+	}
+	return b.registerSingle(b.responders, newResponder(fn, opts), ErrResponderExists)
+}
+
+// newResponder wraps fn in a subscriber storing its answer through the
+// replyKey of the context.
+func newResponder[Q, R any](fn func(ctx context.Context, query Q) (R, error), opts []SubscribeOption) *subscriber {
+	s := &subscriber{
+		key:     reflect.TypeFor[Q](),
+		handler: fn,
+		call: func(ctx context.Context, query any) error {
+			r, err := fn(ctx, query.(Q))
+			if err != nil {
+				return err
+			}
+			if reply, ok := ctx.Value(replyKey{}).(*reply); ok {
+				d, _ := DeliveryFrom(ctx)
+				reply.set(d.Attempt, r)
+			}
+			return nil
+		},
+		priority:   PriorityNormal,
+		subscribed: time.Now(),
+	}
+	for _, opt := range opts {
+		opt.applySubscribe(s)
+	}
+	return s
+}
+
+// registerSingle registers sub as the only handler of its type in m,
+// failing the subscription with dup if there already is one.
+func (b *Bus) registerSingle(m *safemap.Map[reflect.Type, *subscriber], sub *subscriber, dup error) *Subscription {
+	sub.seq = b.seq.Add(1)
+	s := &Subscription{bus: b, key: sub.key, sub: sub}
+	s.remove = func() {
+		m.Compute(sub.key, func(cur *subscriber, _ bool) *subscriber {
+			if cur == sub {
+				return nil
+			}
+			return cur
+		})
+	}
+	if b.inflight.isClosed() {
+		sub.cancelled.Store(true)
+		s.err = ErrClosed
+		return s
+	}
+	if err := b.checkDeprecated(sub.key); err != nil {
+		sub.cancelled.Store(true)
+		s.err = err
+		return s
+	}
+	registered := false
+	m.Compute(sub.key, func(cur *subscriber, _ bool) *subscriber {
+		if cur != nil {
+			return cur
+		}
+		registered = true
+		return sub
+	})
+	if !registered {
+		sub.cancelled.Store(true)
+		s.err = fmt.Errorf("%w: %s", dup, sub.key)
+	}
+	return s
+}
+
+// Request sends query to the responder of Q and returns its answer.
+// Queries are not events: they reach neither the subscribers of Q nor the
+// bus middlewares, but interceptors and the options of the responder
+// apply.
+func Request[Q, R any](ctx context.Context, b *Bus, query Q) (R, error) {
+	if b == nil {
+		b = defaultBus
+	}
+	var zero R
+	if !b.inflight.enter() {
+		return zero, ErrClosed
+	}
+	defer b.inflight.leave()
+
+	key := reflect.TypeFor[Q]()
+	sub, _ := b.responders.Get(key)
+	if sub == nil {
+		return zero, fmt.Errorf("%w: %s", ErrNoResponder, key)
+	}
+	var rep reply
+	ctx = context.WithValue(ctx, replyKey{}, &rep)
+	if err := b.deliver(ctx, sub, query, newEnvelope(key, query)); err != nil {
+		return zero, err
+	}
+	answer, ok := rep.last()
+	if !ok {
+		return zero, fmt.Errorf("%w: %s was declined", ErrNoResponder, key)
+	}
+	return answerAs[R](key, answer)
+}
+
+// answerAs converts the answer of the responder for key to R. A nil answer
+// is the zero R.
+func answerAs[R any](key reflect.Type, answer any) (R, error) {
+	if answer == nil {
+		var zero R
+		return zero, nil
+	}
+	r, ok := answer.(R)
+	if !ok {
+		return r, fmt.Errorf("bus: responder for %s answers %T, not %s", key, answer, reflect.TypeFor[R]())
+	}
+	return r, nil
+}
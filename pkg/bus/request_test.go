@@ -0,0 +1,78 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type GetPrice struct {
+	SKU string
+}
+
+func TestRequest(t *testing.T) {
+	b := bus.New()
+	ctx := context.Background()
+
+	if _, err := bus.Request[GetPrice, float64](ctx, b, GetPrice{SKU: "a"}); !errors.Is(err, bus.ErrNoResponder) {
+		t.Fatalf("Expected ErrNoResponder, got %v", err)
+	}
+
+	sub := bus.Respond(b, func(ctx context.Context, q GetPrice) (float64, error) {
+		if q.SKU == "" {
+			return 0, errors.New("missing sku")
+		}
+		return 9.99, nil
+	})
+	dup := bus.Respond(b, func(ctx context.Context, q GetPrice) (float64, error) { return 0, nil })
+	if sub.Err() != nil || !errors.Is(dup.Err(), bus.ErrResponderExists) {
+		t.Fatalf("Unexpected registration errors: %v, %v", sub.Err(), dup.Err())
+	}
+
+	price, err := bus.Request[GetPrice, float64](ctx, b, GetPrice{SKU: "a"})
+	if err != nil || price != 9.99 {
+		t.Fatalf("Unexpected answer: %v, %v", price, err)
+	}
+	if _, err := bus.Request[GetPrice, float64](ctx, b, GetPrice{}); err == nil {
+		t.Fatal("Expected the responder error")
+	}
+	if _, err := bus.Request[GetPrice, string](ctx, b, GetPrice{SKU: "a"}); err == nil {
+		t.Fatal("Expected an error for a mismatched answer type")
+	}
+
+	sub.Cancel()
+	if _, err := bus.Request[GetPrice, float64](ctx, b, GetPrice{SKU: "a"}); !errors.Is(err, bus.ErrNoResponder) {
+		t.Fatalf("Expected ErrNoResponder after Cancel, got %v", err)
+	}
+}
+
+func TestRequest_NilAnswer(t *testing.T) {
+	b := bus.New()
+	bus.Respond(b, func(ctx context.Context, q GetPrice) (error, error) { return nil, nil })
+
+	answer, err := bus.Request[GetPrice, error](context.Background(), b, GetPrice{SKU: "a"})
+	if err != nil || answer != nil {
+		t.Fatalf("Expected a nil answer, got %v, %v", answer, err)
+	}
+}
+
+func TestRequest_RetriedTimeout(t *testing.T) {
+	b := bus.New()
+	release := make(chan struct{})
+	bus.Respond(b, func(ctx context.Context, q GetPrice) (int, error) {
+		d, _ := bus.DeliveryFrom(ctx)
+		if d.Attempt == 1 {
+			<-release
+		}
+		return d.Attempt, nil
+	}, bus.WithTimeout(10*time.Millisecond), bus.WithRetry(2, time.Millisecond))
+
+	attempt, err := bus.Request[GetPrice, int](context.Background(), b, GetPrice{SKU: "a"})
+	close(release)
+	if err != nil || attempt != 2 {
+		t.Fatalf("Expected the answer of the second attempt, got %v, %v", attempt, err)
+	}
+}
@@ -0,0 +1,58 @@
+package bus
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// maxRetryBackoff caps the delay between two attempts.
+const maxRetryBackoff = time.Minute
+
+type retryPolicy struct {
+	attempts int
+	backoff  time.Duration
+}
+
+// WithRetry invokes the handler up to maxAttempts times until it succeeds.
+// The delay before attempt n+1 is drawn between half and all of
+// backoff·2^(n-1), capped at one minute, so that retrying handlers do not
+// move in lockstep.
+// Only the last failure is reported; DeliveryFrom exposes the attempt
+// number and the previous error to the handler.
+func WithRetry(maxAttempts int, backoff time.Duration) SubscribeOption {
+	return subscribeOption(func(s *subscriber) {
+		s.retry = &retryPolicy{attempts: maxAttempts, backoff: backoff}
+	})
+}
+
+// again waits before the attempt following attempt, reporting false when
+// there is none.
+func (p *retryPolicy) again(ctx context.Context, attempt int) bool {
+	if p == nil || attempt >= p.attempts || ctx.Err() != nil {
+		return false
+	}
+	delay := p.delay(attempt)
+	if delay <= 0 {
+		return true
+	}
+	delay = delay/2 + rand.N(delay/2+1)
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// delay returns the backoff before the attempt following attempt, before
+// jitter.
+func (p *retryPolicy) delay(attempt int) time.Duration {
+	delay := p.backoff
+	for i := 1; i < attempt && delay > 0 && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryBackoff)
+}
@@ -0,0 +1,54 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestRetry(t *testing.T) {
+	b := bus.New()
+	transient := errors.New("transient")
+	var deliveries []bus.Delivery
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		d, _ := bus.DeliveryFrom(ctx)
+		deliveries = append(deliveries, d)
+		if d.Attempt < 3 {
+			return transient
+		}
+		return nil
+	}, bus.WithRetry(5, time.Millisecond))
+
+	if err := bus.Emit(context.Background(), b, OrderCreated{}); err != nil {
+		t.Fatalf("Expected success after retries, got %v", err)
+	}
+	if len(deliveries) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(deliveries))
+	}
+	if deliveries[0].LastError != nil || !errors.Is(deliveries[2].LastError, transient) {
+		t.Fatalf("Unexpected last errors: %v, %v", deliveries[0].LastError, deliveries[2].LastError)
+	}
+}
+
+func TestRetry_Exhausted(t *testing.T) {
+	b := bus.New()
+	errs := bus.Errors(b)
+	attempts := 0
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		attempts++
+		return errors.New("down")
+	}, bus.WithRetry(3, 0))
+
+	if err := bus.Emit(context.Background(), b, OrderCreated{}); err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+	if attempts != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", attempts)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("Expected a single reported failure, got %d", len(errs))
+	}
+}
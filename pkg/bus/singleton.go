@@ -0,0 +1,21 @@
+package bus
+
+import "context"
+
+// Elector decides which of several processes sharing a transport currently
+// owns singleton subscriptions. Implementations typically hold a lease in
+// a shared store and take over when the leader stops renewing it.
+type Elector interface {
+	IsLeader(ctx context.Context) bool
+}
+
+// ElectorFunc adapts a function to the Elector interface.
+type ElectorFunc func(ctx context.Context) bool
+
+func (f ElectorFunc) IsLeader(ctx context.Context) bool { return f(ctx) }
+
+// WithSingleton makes the subscription run only on the process that e
+// reports as leader; deliveries elsewhere are skipped without error.
+func WithSingleton(e Elector) SubscribeOption {
+	return subscribeOption(func(s *subscriber) { s.elector = e })
+}
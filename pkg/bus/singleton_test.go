@@ -0,0 +1,42 @@
+package bus_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestSingleton_Failover(t *testing.T) {
+	var leader atomic.Value
+	leader.Store("a")
+
+	calls := map[string]int{}
+	instances := map[string]*bus.Bus{}
+	for _, id := range []string{"a", "b"} {
+		id := id
+		b := bus.New()
+		instances[id] = b
+		bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+			calls[id]++
+			return nil
+		}, bus.WithSingleton(bus.ElectorFunc(func(ctx context.Context) bool {
+			return leader.Load() == id
+		})))
+	}
+
+	emitAll := func() {
+		for _, b := range instances {
+			_ = bus.Emit(context.Background(), b, &Event{})
+		}
+	}
+
+	emitAll()
+	leader.Store("b")
+	emitAll()
+
+	if calls["a"] != 1 || calls["b"] != 1 {
+		t.Fatalf("Expected one delivery per leadership term, got %v", calls)
+	}
+}
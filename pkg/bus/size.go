@@ -0,0 +1,89 @@
+package bus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var ErrEventTooLarge = errors.New("bus: event too large")
+
+// Sizer reports the size in bytes of an event as it would leave the process.
+type Sizer func(event any) (int, error)
+
+// JSONSizer sizes events by their JSON encoding.
+func JSONSizer(event any) (int, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+type sizeLimit struct {
+	limit int
+	sizer Sizer
+}
+
+func newSizeLimit(limit int, sizer Sizer) sizeLimit {
+	if sizer == nil {
+		sizer = JSONSizer
+	}
+	return sizeLimit{limit: limit, sizer: sizer}
+}
+
+// WithMaxEventSize rejects events larger than limit bytes, as measured by
+// sizer, with ErrEventTooLarge before any handler runs. Events the sizer
+// fails to measure are rejected with its error. A nil sizer defaults to
+// JSONSizer.
+func WithMaxEventSize(limit int, sizer Sizer) Option {
+	l := newSizeLimit(limit, sizer)
+	return func(b *Bus) { b.maxEventSize = l }
+}
+
+// WithMaxEventSizeFor is like WithMaxEventSize but only applies to the
+// event type T, overriding WithMaxEventSize for it.
+func WithMaxEventSizeFor[T any](limit int, sizer Sizer) Option {
+	l := newSizeLimit(limit, sizer)
+	return func(b *Bus) {
+		if b.eventSizes == nil {
+			b.eventSizes = map[reflect.Type]sizeLimit{}
+		}
+		b.eventSizes[reflect.TypeFor[T]()] = l
+	}
+}
+
+// WithOversizeWarnOnly makes the size limits log oversized events, and
+// events the sizer fails to measure, instead of rejecting them, to find
+// out which payloads a limit would reject before enforcing it.
+func WithOversizeWarnOnly() Option {
+	return func(b *Bus) { b.oversizeWarn = true }
+}
+
+func (b *Bus) checkSize(key reflect.Type, event any) error {
+	l, ok := b.eventSizes[key]
+	if !ok {
+		l = b.maxEventSize
+	}
+	if l.sizer == nil {
+		return nil
+	}
+	size, err := l.sizer(event)
+	if err != nil {
+		if b.oversizeWarn {
+			b.logger.Warn("bus: event size unknown", "type", key.String(), "err", err)
+			return nil
+		}
+		return fmt.Errorf("bus: sizing %s: %w", key, err)
+	}
+	if size <= l.limit {
+		return nil
+	}
+	if b.oversizeWarn {
+		b.logger.Warn("bus: oversized event", "type", key.String(), "size", size, "limit", l.limit)
+		return nil
+	}
+	b.logger.Warn("bus: oversized event rejected", "type", key.String(), "size", size, "limit", l.limit)
+	return fmt.Errorf("%w: %s is %d bytes, limit %d", ErrEventTooLarge, key, size, l.limit)
+}
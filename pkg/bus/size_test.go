@@ -0,0 +1,85 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type Attachment struct {
+	Data string
+}
+
+func TestMaxEventSize(t *testing.T) {
+	b := bus.New(
+		bus.WithMaxEventSize(64, nil),
+		bus.WithLogger(slog.New(slog.DiscardHandler)),
+	)
+	calls := 0
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		calls++
+		return nil
+	})
+
+	if err := bus.Emit(context.Background(), b, &Event{Greeting: "Hello"}); err != nil {
+		t.Fatalf("Emit failed: %v", err)
+	}
+
+	err := bus.Emit(context.Background(), b, &Event{Greeting: strings.Repeat("x", 128)})
+	if !errors.Is(err, bus.ErrEventTooLarge) {
+		t.Fatalf("Expected ErrEventTooLarge, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestMaxEventSizeFor(t *testing.T) {
+	var log strings.Builder
+	b := bus.New(
+		bus.WithMaxEventSize(64, nil),
+		bus.WithMaxEventSizeFor[Attachment](1024, nil),
+		bus.WithLogger(slog.New(slog.NewTextHandler(&log, nil))),
+	)
+	big := strings.Repeat("x", 128)
+
+	if err := bus.Emit(context.Background(), b, Attachment{Data: big}); err != nil {
+		t.Fatalf("Expected the per-type limit to apply, got %v", err)
+	}
+	if err := bus.Emit(context.Background(), b, &Event{Greeting: big}); !errors.Is(err, bus.ErrEventTooLarge) {
+		t.Fatalf("Expected ErrEventTooLarge, got %v", err)
+	}
+
+	log.Reset()
+	b = bus.New(
+		bus.WithMaxEventSize(64, nil),
+		bus.WithOversizeWarnOnly(),
+		bus.WithLogger(slog.New(slog.NewTextHandler(&log, nil))),
+	)
+	calls := 0
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		calls++
+		return nil
+	})
+	if err := bus.Emit(context.Background(), b, &Event{Greeting: big}); err != nil {
+		t.Fatalf("Expected warn-only mode to deliver, got %v", err)
+	}
+	if calls != 1 || !strings.Contains(log.String(), "oversized event") {
+		t.Fatalf("Expected delivery and a warning, got %d calls and %q", calls, log.String())
+	}
+
+	bus.Subscribe(b, func(ctx context.Context, e Callback) error {
+		calls++
+		return nil
+	})
+	if err := bus.Emit(context.Background(), b, Callback{Fn: func() {}}); err != nil {
+		t.Fatalf("Expected warn-only mode to deliver unsizable events, got %v", err)
+	}
+	if calls != 2 || !strings.Contains(log.String(), "event size unknown") {
+		t.Fatalf("Expected delivery and a warning, got %d calls and %q", calls, log.String())
+	}
+}
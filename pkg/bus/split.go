@@ -0,0 +1,29 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Split decomposes every T emitted on b into the events returned by fn,
+// which are emitted on b in order, keyed by their dynamic type as with
+// EmitAny. All derived events are emitted even if some fail; their
+// errors are joined. Cancel the returned subscription to stop splitting.
+func Split[T any](b *Bus, fn func(T) []any, opts ...SubscribeOption) *Subscription {
+	if b == nil {
+		b = defaultBus
+	}
+	name := fmt.Sprintf("bus.Split[%s]", reflect.TypeFor[T]())
+	opts = append([]SubscribeOption{WithName(name)}, opts...)
+	return Subscribe(b, func(ctx context.Context, event T) error {
+		var errs []error
+		for _, derived := range fn(event) {
+			if err := EmitAny(ctx, b, derived); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}, opts...)
+}
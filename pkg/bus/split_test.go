@@ -0,0 +1,38 @@
+package bus_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type CheckoutCompleted struct {
+	OrderID  int
+	Customer string
+}
+
+func TestSplit(t *testing.T) {
+	b := bus.New()
+	var orders []int
+	var payments []string
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		orders = append(orders, e.ID)
+		return nil
+	})
+	bus.Subscribe(b, func(ctx context.Context, e *Payment) error {
+		payments = append(payments, e.Customer)
+		return nil
+	})
+
+	bus.Split(b, func(e CheckoutCompleted) []any {
+		return []any{OrderCreated{ID: e.OrderID}, &Payment{Customer: e.Customer}}
+	})
+
+	if err := bus.Emit(context.Background(), b, CheckoutCompleted{OrderID: 3, Customer: "ada"}); err != nil {
+		t.Fatalf("Emit failed: %v", err)
+	}
+	if len(orders) != 1 || orders[0] != 3 || len(payments) != 1 || payments[0] != "ada" {
+		t.Fatalf("Unexpected derived events: orders=%v payments=%v", orders, payments)
+	}
+}
@@ -0,0 +1,62 @@
+package bus
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// SubscriptionStatus reports the activity of a subscription. Zero times
+// mean the event never happened.
+type SubscriptionStatus struct {
+	Type         reflect.Type
+	Handler      HandlerInfo
+	Subscribed   time.Time
+	LastDelivery time.Time
+	LastSuccess  time.Time
+}
+
+// Subscriptions returns the activity of every subscription, ordered like
+// Topology. Wildcard subscriptions have a nil Type.
+func (b *Bus) Subscriptions() []SubscriptionStatus {
+	var out []SubscriptionStatus
+	for _, s := range b.ordered() {
+		out = append(out, SubscriptionStatus{
+			Type:         s.key,
+			Handler:      s.info(),
+			Subscribed:   s.subscribed,
+			LastDelivery: unixTime(s.lastDelivery.Load()),
+			LastSuccess:  unixTime(s.lastSuccess.Load()),
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return fmt.Sprint(out[i].Type) < fmt.Sprint(out[j].Type)
+	})
+	return out
+}
+
+// Stale returns the subscriptions that have not successfully handled an
+// event within window, such as handlers that always fail or whose filters
+// never match. Subscriptions younger than window are not reported.
+func (b *Bus) Stale(window time.Duration) []SubscriptionStatus {
+	cutoff := time.Now().Add(-window)
+	var out []SubscriptionStatus
+	for _, s := range b.Subscriptions() {
+		last := s.LastSuccess
+		if last.IsZero() {
+			last = s.Subscribed
+		}
+		if last.Before(cutoff) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func unixTime(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
@@ -0,0 +1,35 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func healthyHandler(ctx context.Context, e *Event) error { return nil }
+func brokenHandler(ctx context.Context, e *Event) error  { return errors.New("always fails") }
+
+func TestStale(t *testing.T) {
+	b := bus.New(bus.WithStrategy(bus.BestEffort))
+	bus.Subscribe(b, healthyHandler)
+	bus.Subscribe(b, brokenHandler)
+
+	if stale := b.Stale(time.Millisecond); len(stale) != 0 {
+		t.Fatalf("Expected fresh subscriptions not to be stale, got %d", len(stale))
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	_ = bus.Emit(context.Background(), b, &Event{})
+
+	stale := b.Stale(time.Millisecond)
+	if len(stale) != 1 || !strings.HasSuffix(stale[0].Handler.Name, "brokenHandler") {
+		t.Fatalf("Expected only brokenHandler to be stale, got %+v", stale)
+	}
+	if stale[0].LastDelivery.IsZero() || !stale[0].LastSuccess.IsZero() {
+		t.Fatalf("Unexpected activity: %+v", stale[0])
+	}
+}
@@ -0,0 +1,171 @@
+package bus
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// WithSticky makes every emission of T sticky, as with EmitSticky.
+func WithSticky[T any]() Option {
+	return func(b *Bus) {
+		if b.stickyTypes == nil {
+			b.stickyTypes = map[reflect.Type]bool{}
+		}
+		b.stickyTypes[reflect.TypeFor[T]()] = true
+	}
+}
+
+// EmitSticky is like Emit but retains event as the current value of T:
+// handlers subscribed later receive it before any live event, until the
+// next sticky emission of T or ClearSticky. The value is delivered in the
+// background, after Subscribe returns.
+func EmitSticky[T any](ctx context.Context, b *Bus, event T) error {
+	if b == nil {
+		b = defaultBus
+	}
+	env := newEnvelope(reflect.TypeFor[T](), event)
+	env.sticky = true
+	return b.send(ctx, env)
+}
+
+// Sticky returns the current sticky value of T.
+func Sticky[T any](b *Bus) (T, bool) {
+	if b == nil {
+		b = defaultBus
+	}
+	v, ok := b.sticky.Get(reflect.TypeFor[T]())
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return v.event.(T), true
+}
+
+// ClearSticky forgets the sticky value of T.
+func ClearSticky[T any](b *Bus) {
+	if b == nil {
+		b = defaultBus
+	}
+	b.sticky.Delete(reflect.TypeFor[T]())
+}
+
+// retained is an event kept for later subscribers.
+type retained struct {
+	id    uint64
+	event any
+}
+
+// retain keeps event for later subscribers as a sticky value or in the
+// replay buffer of its type.
+func (b *Bus) retain(env envelope, event any) {
+	if env.topic != "" {
+		return
+	}
+	r := retained{id: env.id, event: event}
+	if env.sticky || b.stickyTypes[env.key] {
+		b.sticky.Set(env.key, r)
+	}
+	if ring, ok := b.replays[env.key]; ok {
+		ring.add(r)
+	}
+}
+
+// backlog holds the live deliveries to a new subscriber until it has
+// caught up with the retained events of its type.
+type backlog struct {
+	live    atomic.Bool
+	mu      sync.Mutex
+	pending []heldDelivery
+}
+
+type heldDelivery struct {
+	ctx   context.Context
+	event any
+	env   envelope
+}
+
+// hold queues a live delivery while the subscriber catches up. It reports
+// false once the subscriber is live.
+func (q *backlog) hold(ctx context.Context, event any, env envelope) bool {
+	if q == nil || q.live.Load() {
+		return false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.live.Load() {
+		return false
+	}
+	q.pending = append(q.pending, heldDelivery{context.WithoutCancel(ctx), event, env})
+	return true
+}
+
+// next returns the held deliveries, marking the subscriber live when
+// there are none left.
+func (q *backlog) next() []heldDelivery {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	pending := q.pending
+	q.pending = nil
+	if len(pending) == 0 {
+		q.live.Store(true)
+	}
+	return pending
+}
+
+// settle marks the subscriber live if no delivery is held, reporting
+// whether it is live.
+func (q *backlog) settle() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.pending) == 0 {
+		q.live.Store(true)
+	}
+	return q.live.Load()
+}
+
+// catchUp delivers to a new subscriber, in the background, the replay
+// history or the sticky value of its type and then the live events held
+// meanwhile, so that it sees them in order. It must be called once s is
+// registered.
+func (b *Bus) catchUp(s *subscriber) {
+	var events []retained
+	if ring, ok := b.replays[s.key]; ok && s.replay {
+		events = ring.snapshot()
+	} else if r, ok := b.sticky.Get(s.key); ok {
+		events = []retained{r}
+	}
+	if len(events) == 0 && s.backlog.settle() {
+		return
+	}
+	if !b.inflight.enter() {
+		s.backlog.live.Store(true)
+		return
+	}
+	go func() {
+		defer b.inflight.leave()
+		// Events retained while s was being registered may also have been
+		// held: deliver them once.
+		seen := make(map[uint64]bool, len(events))
+		for _, r := range events {
+			seen[r.id] = true
+			b.redeliver(context.Background(), s, r.event, newEnvelope(s.key, r.event))
+		}
+		for held := s.backlog.next(); len(held) > 0; held = s.backlog.next() {
+			for _, h := range held {
+				if !seen[h.env.id] {
+					b.redeliver(h.ctx, s, h.event, h.env)
+				}
+			}
+		}
+	}()
+}
+
+// redeliver delivers event to s outside of a dispatch, reporting its
+// failure.
+func (b *Bus) redeliver(ctx context.Context, s *subscriber, event any, env envelope) {
+	if err := b.deliverNow(ctx, s, event, env); err != nil {
+		b.fail(ctx, s, DispatchError{Handler: s.info(), Type: s.key, Event: event, Priority: s.priority, Async: true, Err: err})
+	}
+}
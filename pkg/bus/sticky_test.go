@@ -0,0 +1,109 @@
+package bus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type ConfigChanged struct {
+	Version int
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for delivery")
+		panic("unreachable")
+	}
+}
+
+func TestEmitSticky(t *testing.T) {
+	b := bus.New()
+	ctx := context.Background()
+	_ = bus.EmitSticky(ctx, b, ConfigChanged{Version: 1})
+	_ = bus.EmitSticky(ctx, b, ConfigChanged{Version: 2})
+
+	got := make(chan int, 4)
+	bus.Subscribe(b, func(ctx context.Context, e ConfigChanged) error {
+		got <- e.Version
+		return nil
+	})
+	if v := receive(t, got); v != 2 {
+		t.Fatalf("Expected latest sticky value on subscribe, got %d", v)
+	}
+
+	_ = bus.Emit(ctx, b, ConfigChanged{Version: 3})
+	if v, _ := bus.Sticky[ConfigChanged](b); v.Version != 2 {
+		t.Fatalf("Expected plain Emit not to replace the sticky value, got %d", v.Version)
+	}
+
+	bus.ClearSticky[ConfigChanged](b)
+	late := make(chan int, 1)
+	bus.Subscribe(b, func(ctx context.Context, e ConfigChanged) error {
+		late <- e.Version
+		return nil
+	})
+	_ = b.Close(ctx)
+	if len(late) != 0 {
+		t.Fatal("Expected no replay after ClearSticky")
+	}
+}
+
+func TestWithSticky(t *testing.T) {
+	b := bus.New(bus.WithSticky[ConfigChanged]())
+	_ = bus.Emit(context.Background(), b, ConfigChanged{Version: 5})
+
+	got := make(chan int, 1)
+	bus.Subscribe(b, func(ctx context.Context, e ConfigChanged) error {
+		got <- e.Version
+		return nil
+	})
+	if v := receive(t, got); v != 5 {
+		t.Fatalf("Expected sticky replay of 5, got %d", v)
+	}
+}
+
+func TestEmitSticky_SubscribeOnce(t *testing.T) {
+	b := bus.New()
+	ctx := context.Background()
+	_ = bus.EmitSticky(ctx, b, ConfigChanged{Version: 1})
+
+	got := make(chan int, 2)
+	sub := bus.SubscribeOnce(b, func(ctx context.Context, e ConfigChanged) error {
+		got <- e.Version
+		return nil
+	})
+	if v := receive(t, got); v != 1 {
+		t.Fatalf("Expected the sticky value, got %d", v)
+	}
+	_ = b.Close(ctx)
+	if sub.Active() || len(got) != 0 {
+		t.Fatal("Expected the subscription cancelled after one delivery")
+	}
+}
+
+func TestEmitSticky_CurrentValueFirst(t *testing.T) {
+	b := bus.New()
+	ctx := context.Background()
+	_ = bus.EmitSticky(ctx, b, ConfigChanged{Version: 1})
+
+	got := make(chan int, 16)
+	bus.Subscribe(b, func(ctx context.Context, e ConfigChanged) error {
+		got <- e.Version
+		return nil
+	})
+	for v := 2; v <= 5; v++ {
+		_ = bus.Emit(ctx, b, ConfigChanged{Version: v})
+	}
+	for want := 1; want <= 5; want++ {
+		if v := receive(t, got); v != want {
+			t.Fatalf("Expected version %d, got %d", want, v)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Target is a handler taking part in a dispatch. Deliver runs it, applying
+// its filters and the bus interceptors. Its failure is reported to Errors
+// and the dead letters once the strategy returns, if the error of the
+// strategy wraps it.
+type Target struct {
+	HandlerInfo
+	Deliver func(ctx context.Context, event any) error
+}
+
+// Strategy decides how a dispatch runs its handlers, given in priority
+// order, and which error the emission returns. DispatchStrategy values,
+// Parallel, FirstSuccess and CancelRemainingOnError are built in.
+type Strategy interface {
+	Execute(ctx context.Context, targets []Target, event any) error
+}
+
+func (s DispatchStrategy) Execute(ctx context.Context, targets []Target, event any) error {
+	if s == BestEffort {
+		var errs []error
+		for _, t := range targets {
+			if err := t.Deliver(ctx, event); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+	for _, t := range targets {
+		if err := t.Deliver(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Parallel runs all handlers concurrently and joins their errors.
+var Parallel Strategy = parallel{}
+
+// FirstSuccess runs handlers until one succeeds, for fallback chains,
+// and joins their errors only if all fail. The failures before a
+// success are not reported to Errors or the dead letters.
+var FirstSuccess Strategy = firstSuccess{}
+
+// CancelRemainingOnError runs all handlers concurrently and, on the
+// first error, cancels the context of those still running. The first
+// error is returned, and is the only one reported to Errors and the
+// dead letters.
+var CancelRemainingOnError Strategy = cancelRemaining{}
+
+type parallel struct{}
+
+func (parallel) Execute(ctx context.Context, targets []Target, event any) error {
+	errs := make([]error, len(targets))
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = t.Deliver(ctx, event)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+type firstSuccess struct{}
+
+func (firstSuccess) Execute(ctx context.Context, targets []Target, event any) error {
+	var errs []error
+	for _, t := range targets {
+		err := t.Deliver(ctx, event)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+type cancelRemaining struct{}
+
+func (cancelRemaining) Execute(ctx context.Context, targets []Target, event any) error {
+	g, ctx := errgroup.WithContext(ctx)
+	for _, t := range targets {
+		g.Go(func() error { return t.Deliver(ctx, event) })
+	}
+	return g.Wait()
+}
+
+// ErrQuorum is returned when fewer handlers than required succeeded.
+var ErrQuorum = errors.New("bus: quorum not reached")
+
+type quorum int
+
+// QuorumDegraded is emitted on the bus when an emission succeeds although
+// some handlers failed, as reported by a strategy returning DegradedError.
+type QuorumDegraded struct {
+	Type      reflect.Type
+	Event     any
+	Succeeded int
+	Required  int
+	Err       error
+}
+
+// DegradedError is returned by a strategy whose dispatch succeeded
+// although some handlers failed. The bus does not fail the emission but
+// emits QuorumDegraded, also when a wrapping strategy returns the error
+// wrapped.
+type DegradedError struct {
+	Succeeded int
+	Required  int
+	Err       error
+}
+
+func (e *DegradedError) Error() string {
+	return fmt.Sprintf("bus: degraded dispatch, %d handlers succeeded, %d required: %v", e.Succeeded, e.Required, e.Err)
+}
+
+func (e *DegradedError) Unwrap() error {
+	return e.Err
+}
+
+// Quorum runs every handler and succeeds if at least n of them do, in
+// which case failed handlers are reported with QuorumDegraded. Otherwise
+// the emission fails with ErrQuorum joined with the handler errors.
+func Quorum(n int) Strategy {
+	return quorum(n)
+}
+
+func (q quorum) Execute(ctx context.Context, targets []Target, event any) error {
+	var errs []error
+	for _, t := range targets {
+		if err := t.Deliver(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	ok := len(targets) - len(errs)
+	if ok < int(q) {
+		return errors.Join(append([]error{fmt.Errorf("%w: %d of %d required handlers succeeded", ErrQuorum, ok, int(q))}, errs...)...)
+	}
+	if len(errs) > 0 {
+		return &DegradedError{Succeeded: ok, Required: int(q), Err: errors.Join(errs...)}
+	}
+	return nil
+}
+
+// ErrFailureBudget is returned when a budgeted BestEffort dispatch skips
+// its remaining handlers.
+var ErrFailureBudget = errors.New("bus: failure budget exhausted")
+
+type failureBudget struct {
+	max   int
+	ratio float64
+}
+
+// BestEffortMaxFailures is like BestEffort but skips the remaining
+// handlers once more than n have failed, failing with ErrFailureBudget
+// joined with the handler errors.
+func BestEffortMaxFailures(n int) Strategy {
+	return failureBudget{max: n}
+}
+
+// BestEffortMaxFailureRatio is like BestEffortMaxFailures with the budget
+// given as a fraction, between 0 and 1, of the handlers of the dispatch.
+func BestEffortMaxFailureRatio(r float64) Strategy {
+	return failureBudget{ratio: r}
+}
+
+func (f failureBudget) Execute(ctx context.Context, targets []Target, event any) error {
+	limit := f.max
+	if f.ratio > 0 {
+		limit = int(f.ratio * float64(len(targets)))
+	}
+	var errs []error
+	for i, t := range targets {
+		if err := t.Deliver(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+		if len(errs) > limit && i < len(targets)-1 {
+			skipped := fmt.Errorf("%w: %d failures, %d handlers skipped", ErrFailureBudget, len(errs), len(targets)-i-1)
+			return errors.Join(append([]error{skipped}, errs...)...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// execute runs targets with the strategy of env, or of the bus.
+func (b *Bus) execute(ctx context.Context, env envelope, targets []Target, event any) error {
+	strategy := b.strategy
+	if env.strategy != nil {
+		strategy = env.strategy
+	}
+	err := strategy.Execute(ctx, targets, event)
+	var degraded *DegradedError
+	if !errors.As(err, &degraded) {
+		return err
+	}
+	_ = dispatch(ctx, b, newEnvelope(reflect.TypeFor[QuorumDegraded](), QuorumDegraded{
+		Type:      env.key,
+		Event:     event,
+		Succeeded: degraded.Succeeded,
+		Required:  degraded.Required,
+		Err:       degraded.Err,
+	}))
+	return nil
+}
+
+// failure is a handler failure held until the strategy of its dispatch
+// has decided the outcome.
+type failure struct {
+	ctx      context.Context
+	sub      *subscriber
+	err      *HandlerError
+	dispatch DispatchError
+}
+
+type failures struct {
+	mu   sync.Mutex
+	list []failure
+}
+
+func (f *failures) add(x failure) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.list = append(f.list, x)
+}
+
+// reportFailures sends to Errors and the dead letters the failures that
+// decided outcome, the error returned by the strategy. Failures the
+// strategy tolerated, such as the misses before a FirstSuccess handler
+// succeeds or the siblings cancelled by CancelRemainingOnError, are not
+// reported. If outcome does not wrap any handler error, all failures are.
+func (b *Bus) reportFailures(outcome error, f *failures) {
+	if outcome == nil {
+		return
+	}
+	f.mu.Lock()
+	list := f.list
+	f.mu.Unlock()
+	decisive := list[:0:0]
+	for _, x := range list {
+		if errors.Is(outcome, x.err) {
+			decisive = append(decisive, x)
+		}
+	}
+	if len(decisive) == 0 {
+		decisive = list
+	}
+	for _, x := range decisive {
+		b.fail(x.ctx, x.sub, x.dispatch)
+	}
+}
@@ -0,0 +1,252 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestStrategy_Parallel(t *testing.T) {
+	b := bus.New(bus.WithStrategy(bus.Parallel))
+	var running atomic.Int32
+	both := make(chan struct{})
+	for range 2 {
+		bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+			if running.Add(1) == 2 {
+				close(both)
+			}
+			select {
+			case <-both:
+				return errors.New("fail")
+			case <-time.After(time.Second):
+				return errors.New("handlers did not run concurrently")
+			}
+		})
+	}
+
+	err := bus.Emit(context.Background(), b, &Event{})
+	var he *bus.HandlerError
+	if !errors.As(err, &he) || he.Err.Error() != "fail" || len(err.(interface{ Unwrap() []error }).Unwrap()) != 2 {
+		t.Fatalf("Expected joined errors, got %v", err)
+	}
+}
+
+func TestStrategy_CancelRemainingOnError(t *testing.T) {
+	var dead []bus.DispatchError
+	b := bus.New(bus.WithStrategy(bus.CancelRemainingOnError), bus.WithDeadLetter(func(ctx context.Context, failed bus.DispatchError) {
+		dead = append(dead, failed)
+	}))
+	boom := errors.New("boom")
+	started := make(chan struct{})
+	var cancelled atomic.Bool
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		close(started)
+		select {
+		case <-ctx.Done():
+			cancelled.Store(true)
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return nil
+		}
+	})
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		<-started
+		return boom
+	})
+
+	err := bus.Emit(context.Background(), b, &Event{})
+	if !errors.Is(err, boom) {
+		t.Fatalf("Expected boom, got %v", err)
+	}
+	if !cancelled.Load() {
+		t.Fatal("Expected the in-flight handler to be cancelled")
+	}
+	if len(dead) != 1 || !errors.Is(dead[0].Err, boom) {
+		t.Fatalf("Expected only the decisive failure dead-lettered, got %v", dead)
+	}
+}
+
+func TestStrategy_FirstSuccess(t *testing.T) {
+	var dead []bus.DispatchError
+	b := bus.New(bus.WithStrategy(bus.FirstSuccess), bus.WithDeadLetter(func(ctx context.Context, failed bus.DispatchError) {
+		dead = append(dead, failed)
+	}))
+	miss := errors.New("cache miss")
+	var tried []string
+	try := func(name string, err *error) bus.Handler[*Event] {
+		return func(ctx context.Context, e *Event) error {
+			tried = append(tried, name)
+			return *err
+		}
+	}
+	var cacheErr, dbErr, remoteErr error = miss, nil, nil
+	bus.Subscribe(b, try("cache", &cacheErr), bus.PriorityHigh)
+	bus.Subscribe(b, try("db", &dbErr))
+	bus.Subscribe(b, try("remote", &remoteErr), bus.PriorityLow)
+
+	if err := bus.Emit(context.Background(), b, &Event{}); err != nil {
+		t.Fatalf("Expected success from the fallback, got %v", err)
+	}
+	if len(tried) != 2 || tried[1] != "db" {
+		t.Fatalf("Expected chain to stop at db, got %v", tried)
+	}
+	if len(dead) != 0 {
+		t.Fatalf("Expected the fallback miss not dead-lettered, got %v", dead)
+	}
+
+	dbErr, remoteErr = errors.New("db down"), errors.New("timeout")
+	tried = nil
+	err := bus.Emit(context.Background(), b, &Event{})
+	if !errors.Is(err, miss) || !errors.Is(err, remoteErr) || len(tried) != 3 {
+		t.Fatalf("Expected joined errors after %v, got %v", tried, err)
+	}
+	if len(dead) != 3 {
+		t.Fatalf("Expected every failure dead-lettered, got %v", dead)
+	}
+}
+
+func TestStrategy_BestEffortMaxFailures(t *testing.T) {
+	for _, s := range []bus.Strategy{bus.BestEffortMaxFailures(1), bus.BestEffortMaxFailureRatio(0.25)} {
+		b := bus.New(bus.WithStrategy(s))
+		fail := errors.New("downstream unavailable")
+		calls := 0
+		for range 5 {
+			bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+				calls++
+				return fail
+			})
+		}
+
+		err := bus.Emit(context.Background(), b, &Event{})
+		if !errors.Is(err, bus.ErrFailureBudget) || !errors.Is(err, fail) {
+			t.Fatalf("Expected ErrFailureBudget, got %v", err)
+		}
+		if calls != 2 {
+			t.Fatalf("Expected handlers skipped after 2 failures, got %d calls", calls)
+		}
+	}
+}
+
+func TestStrategy_Quorum(t *testing.T) {
+	b := bus.New(bus.WithStrategy(bus.Quorum(2)))
+	fail := errors.New("replica down")
+	var failing atomic.Int32
+	var degraded []bus.QuorumDegraded
+	bus.Subscribe(b, func(ctx context.Context, e bus.QuorumDegraded) error {
+		degraded = append(degraded, e)
+		return nil
+	})
+	for i := range 3 {
+		bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+			if int32(i) < failing.Load() {
+				return fail
+			}
+			return nil
+		})
+	}
+
+	if err := bus.Emit(context.Background(), b, &Event{}); err != nil || len(degraded) != 0 {
+		t.Fatalf("Expected clean success, got %v, %v", err, degraded)
+	}
+	failing.Store(1)
+	if err := bus.Emit(context.Background(), b, &Event{}); err != nil {
+		t.Fatalf("Expected quorum reached, got %v", err)
+	}
+	if len(degraded) != 1 || degraded[0].Succeeded != 2 || degraded[0].Required != 2 || !errors.Is(degraded[0].Err, fail) {
+		t.Fatalf("Expected partial success reported, got %+v", degraded)
+	}
+	failing.Store(2)
+	err := bus.Emit(context.Background(), b, &Event{})
+	if !errors.Is(err, bus.ErrQuorum) || !errors.Is(err, fail) || len(degraded) != 1 {
+		t.Fatalf("Expected ErrQuorum, got %v", err)
+	}
+}
+
+func TestStrategy_QuorumWithoutHandlers(t *testing.T) {
+	b := bus.New(bus.WithStrategy(bus.Quorum(2)))
+	if err := bus.Emit(context.Background(), b, &Event{}); !errors.Is(err, bus.ErrQuorum) {
+		t.Fatalf("Expected ErrQuorum without handlers, got %v", err)
+	}
+}
+
+type firstOnly struct{}
+
+func (firstOnly) Execute(ctx context.Context, targets []bus.Target, event any) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	return targets[0].Deliver(ctx, event)
+}
+
+func TestStrategy_Custom(t *testing.T) {
+	b := bus.New(bus.WithStrategy(firstOnly{}))
+	var calls []string
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		calls = append(calls, "low")
+		return nil
+	}, bus.PriorityLow)
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		calls = append(calls, "high")
+		return nil
+	}, bus.PriorityHigh)
+
+	_ = bus.Emit(context.Background(), b, &Event{})
+	if len(calls) != 1 || calls[0] != "high" {
+		t.Fatalf("Unexpected calls: %v", calls)
+	}
+}
+
+type counted struct {
+	bus.Strategy
+	runs *int
+}
+
+func (c counted) Execute(ctx context.Context, targets []bus.Target, event any) error {
+	*c.runs++
+	if err := c.Strategy.Execute(ctx, targets, event); err != nil {
+		return fmt.Errorf("run %d: %w", *c.runs, err)
+	}
+	return nil
+}
+
+func TestStrategy_WrappedQuorum(t *testing.T) {
+	runs := 0
+	b := bus.New(bus.WithStrategy(counted{Strategy: bus.Quorum(1), runs: &runs}))
+	var degraded []bus.QuorumDegraded
+	bus.Subscribe(b, func(ctx context.Context, e bus.QuorumDegraded) error {
+		degraded = append(degraded, e)
+		return nil
+	})
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error { return errors.New("replica down") })
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error { return nil })
+
+	if err := bus.Emit(context.Background(), b, &Event{}); err != nil {
+		t.Fatalf("Expected quorum reached, got %v", err)
+	}
+	if len(degraded) != 1 || degraded[0].Succeeded != 1 || degraded[0].Required != 1 {
+		t.Fatalf("Expected the wrapped strategy to report degradation, got %+v", degraded)
+	}
+}
+
+func TestStrategy_Nil(t *testing.T) {
+	b := bus.New(bus.WithStrategy(nil))
+	boom := errors.New("boom")
+	calls := 0
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		calls++
+		return boom
+	})
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		calls++
+		return nil
+	})
+
+	if err := bus.Emit(context.Background(), b, &Event{}); !errors.Is(err, boom) || calls != 1 {
+		t.Fatalf("Expected StopOnFirstError, got %v after %d calls", err, calls)
+	}
+}
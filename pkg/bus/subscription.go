@@ -0,0 +1,352 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SubscribeOption configures a single subscription. A Priority is itself
+// a SubscribeOption.
+type SubscribeOption interface {
+	applySubscribe(s *subscriber)
+}
+
+type subscribeOption func(s *subscriber)
+
+func (o subscribeOption) applySubscribe(s *subscriber) { o(s) }
+
+func (p Priority) applySubscribe(s *subscriber) { s.priority = p }
+
+type subscriber struct {
+	key      reflect.Type
+	name     string
+	handler  any
+	call     func(ctx context.Context, event any) error
+	priority Priority
+	seq      uint64
+	timeout  time.Duration
+
+	cancelled atomic.Bool
+
+	lifecycle Lifecycle
+	running   atomic.Bool
+	elector   Elector
+	matcher   Matcher
+
+	finalizers []Finalizer
+	replay     bool
+	deadLetter DeadLetter
+	retry      *retryPolicy
+	breaker    *breaker
+	budget     *EmitBudget
+	after      []string
+	backlog    *backlog
+
+	subscribed   time.Time
+	lastDelivery atomic.Int64
+	lastSuccess  atomic.Int64
+
+	initFn  func(ctx context.Context) error
+	initMu  sync.Mutex
+	initErr error
+	ready   bool
+}
+
+func newSubscriber[T any](fn Handler[T], opts []SubscribeOption) *subscriber {
+	s := &subscriber{
+		key:     reflect.TypeFor[T](),
+		handler: fn,
+		call: func(ctx context.Context, event any) error {
+			return fn(ctx, event.(T))
+		},
+		priority:   PriorityNormal,
+		subscribed: time.Now(),
+	}
+	for _, opt := range opts {
+		opt.applySubscribe(s)
+	}
+	return s
+}
+
+// Matcher decides whether an event should be delivered to a subscription.
+// Compiled expressions from the rules package implement it.
+type Matcher interface {
+	Match(event any) bool
+}
+
+// WithMatcher skips deliveries of events that m does not match. Multiple
+// matchers and filters must all match.
+func WithMatcher(m Matcher) SubscribeOption {
+	return subscribeOption(func(s *subscriber) {
+		mm := m
+		if s.matcher != nil {
+			mm = allOf{s.matcher, m}
+		}
+		s.matcher = mm
+	})
+}
+
+// WithFilter skips deliveries of events for which pred returns false.
+// The predicate runs before the handler and its interceptors.
+func WithFilter[T any](pred func(T) bool) SubscribeOption {
+	return WithMatcher(filter[T](pred))
+}
+
+type filter[T any] func(T) bool
+
+func (f filter[T]) Match(event any) bool {
+	e, ok := event.(T)
+	return ok && f(e)
+}
+
+type allOf [2]Matcher
+
+func (m allOf) Match(event any) bool {
+	return m[0].Match(event) && m[1].Match(event)
+}
+
+// WithName names the handler in topologies, audits and errors. By default
+// the handler is named after its function.
+func WithName(name string) SubscribeOption {
+	return subscribeOption(func(s *subscriber) { s.name = name })
+}
+
+// Subscription is a handle to a registered handler.
+type Subscription struct {
+	bus   *Bus
+	key   reflect.Type
+	topic string
+	sub   *subscriber
+	err   error
+	// remove, when set, unregisters sub from outside the subscriber lists.
+	remove func()
+
+	mu   sync.Mutex
+	stop func() bool
+}
+
+// Cancel removes the handler from the bus. Dispatches already in progress
+// skip it as well. Cancel is idempotent and safe for concurrent use.
+func (s *Subscription) Cancel() {
+	if !s.sub.cancelled.CompareAndSwap(false, true) {
+		return
+	}
+	switch {
+	case s.remove != nil:
+		s.remove()
+	case s.key == nil:
+		s.bus.removeWildcard(s.sub)
+	case isPattern(s.topic):
+		s.bus.patterns.remove(s.key, s.topic, s.sub)
+	case s.topic != "":
+		s.bus.topics.Compute(topicKey{s.key, s.topic}, without(s.sub))
+	default:
+		s.bus.subscribers.Compute(s.key, without(s.sub))
+		s.bus.table.invalidate()
+	}
+
+	s.mu.Lock()
+	stop := s.stop
+	s.mu.Unlock()
+	if stop != nil {
+		stop()
+	}
+}
+
+// insert returns a Compute function adding s to a subscriber list, which
+// is copied so that dispatches in progress keep their snapshot.
+func insert(s *subscriber) func([]*subscriber, bool) []*subscriber {
+	return func(subs []*subscriber, _ bool) []*subscriber {
+		newSubs := make([]*subscriber, len(subs), len(subs)+1)
+		copy(newSubs, subs)
+		newSubs = append(newSubs, s)
+		_ = arrange(newSubs)
+		return newSubs
+	}
+}
+
+// without returns a Compute function removing s from a subscriber list.
+func without(s *subscriber) func([]*subscriber, bool) []*subscriber {
+	return func(subs []*subscriber, _ bool) []*subscriber {
+		newSubs := make([]*subscriber, 0, len(subs))
+		for _, sub := range subs {
+			if sub != s {
+				newSubs = append(newSubs, sub)
+			}
+		}
+		return newSubs
+	}
+}
+
+// Active reports whether the subscription has not been cancelled.
+func (s *Subscription) Active() bool {
+	return !s.sub.cancelled.Load()
+}
+
+// Err reports why the subscription could not be registered, such as
+// ErrClosed for a closed bus.
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+// WithInit registers fn to prepare the handler before its first delivery.
+// Until fn succeeds, deliveries fail with its error and the failure is
+// reported by Bus.Health; fn is retried on the next delivery.
+func WithInit(fn func(ctx context.Context) error) SubscribeOption {
+	return subscribeOption(func(s *subscriber) { s.initFn = fn })
+}
+
+// accepts reports whether event should reach the handler at all.
+func (s *subscriber) accepts(ctx context.Context, event any) bool {
+	if s.cancelled.Load() {
+		return false
+	}
+	s.lastDelivery.Store(time.Now().UnixNano())
+	if s.elector != nil && !s.elector.IsLeader(ctx) {
+		return false
+	}
+	return s.matcher == nil || s.matcher.Match(event)
+}
+
+// WithTimeout bounds each invocation of the handler to d. The handler's
+// context carries the deadline; once it passes, the delivery fails with
+// context.DeadlineExceeded and the dispatch moves on without waiting for
+// the handler to return.
+func WithTimeout(d time.Duration) SubscribeOption {
+	return subscribeOption(func(s *subscriber) { s.timeout = d })
+}
+
+func (s *subscriber) handle(ctx context.Context, event any) error {
+	if err := s.initialize(ctx); err != nil {
+		return err
+	}
+	if err := s.invoke(ctx, event); err != nil {
+		return err
+	}
+	s.lastSuccess.Store(time.Now().UnixNano())
+	return nil
+}
+
+func (s *subscriber) invoke(ctx context.Context, event any) error {
+	if s.timeout <= 0 {
+		return s.call(ctx, event)
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	type result struct {
+		err      error
+		panicked bool
+		value    any
+	}
+	done := make(chan result, 1)
+	go func() {
+		panicked := true
+		defer func() {
+			if panicked {
+				done <- result{panicked: true, value: recover()}
+			}
+		}()
+		err := s.call(ctx, event)
+		panicked = false
+		done <- result{err: err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.panicked {
+			panic(r.value)
+		}
+		return r.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *subscriber) initialize(ctx context.Context) error {
+	if s.initFn == nil {
+		return nil
+	}
+	s.initMu.Lock()
+	defer s.initMu.Unlock()
+	if s.ready {
+		return nil
+	}
+	if err := s.initFn(ctx); err != nil {
+		s.initErr = fmt.Errorf("bus: init of %s failed: %w", s.info().Name, err)
+		return s.initErr
+	}
+	s.initErr = nil
+	s.ready = true
+	return nil
+}
+
+// Health reports the subscriptions whose last initialization failed.
+func (b *Bus) Health() error {
+	var errs []error
+	for _, s := range b.registered() {
+		s.initMu.Lock()
+		if s.initErr != nil {
+			errs = append(errs, s.initErr)
+		}
+		s.initMu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// onceKey marks the context of SubscribeOnce handlers with the locks of
+// the invocations running, so that the events they emit synchronously do
+// not wait for them.
+type onceKey struct{}
+
+// SubscribeOnce is like Subscribe but removes the handler after its first
+// successful invocation. Failed invocations keep the subscription active;
+// concurrent deliveries wait for the running invocation instead of being
+// skipped.
+func SubscribeOnce[T any](b *Bus, fn Handler[T], opts ...SubscribeOption) *Subscription {
+	var (
+		mu   sync.Mutex
+		done bool
+		sub  *Subscription
+	)
+	// Retained events reach the handler in the background, possibly before
+	// Subscribe returns: wait for sub before cancelling it.
+	ready := make(chan struct{})
+	sub = Subscribe(b, func(ctx context.Context, event T) error {
+		running, _ := ctx.Value(onceKey{}).([]*sync.Mutex)
+		if slices.Contains(running, &mu) {
+			return nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if done {
+			return nil
+		}
+		ctx = context.WithValue(ctx, onceKey{}, append(running[:len(running):len(running)], &mu))
+		if err := fn(ctx, event); err != nil {
+			return err
+		}
+		done = true
+		<-ready
+		sub.Cancel()
+		return nil
+	}, opts...)
+	close(ready)
+	return sub
+}
+
+// SubscribeCtx is like Subscribe but cancels the subscription when ctx is
+// done, tying the handler to the lifetime of a request or component.
+func SubscribeCtx[T any](ctx context.Context, b *Bus, fn Handler[T], opts ...SubscribeOption) *Subscription {
+	sub := Subscribe(b, fn, opts...)
+	sub.mu.Lock()
+	sub.stop = context.AfterFunc(ctx, sub.Cancel)
+	sub.mu.Unlock()
+	return sub
+}
@@ -0,0 +1,283 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestSubscription_Init(t *testing.T) {
+	b := bus.New()
+	inits, calls := 0, 0
+	fail := true
+
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		calls++
+		return nil
+	}, bus.WithInit(func(ctx context.Context) error {
+		inits++
+		if fail {
+			return errors.New("db unavailable")
+		}
+		return nil
+	}))
+
+	if err := bus.Emit(context.Background(), b, &Event{}); err == nil {
+		t.Fatal("Expected init error")
+	}
+	if calls != 0 {
+		t.Fatal("Handler called before successful init")
+	}
+	if b.Health() == nil {
+		t.Fatal("Expected health to report init failure")
+	}
+
+	fail = false
+	for i := 0; i < 3; i++ {
+		if err := bus.Emit(context.Background(), b, &Event{}); err != nil {
+			t.Fatalf("Emit failed: %v", err)
+		}
+	}
+	if inits != 2 || calls != 3 {
+		t.Fatalf("Expected 2 inits and 3 calls, got %d and %d", inits, calls)
+	}
+	if err := b.Health(); err != nil {
+		t.Fatalf("Expected healthy bus, got %v", err)
+	}
+}
+
+func TestSubscription_Cancel(t *testing.T) {
+	b := bus.New()
+	var got []string
+
+	first := bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		got = append(got, "first")
+		return nil
+	})
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		got = append(got, "second")
+		return nil
+	})
+
+	_ = bus.Emit(context.Background(), b, &Event{})
+	first.Cancel()
+	first.Cancel()
+	_ = bus.Emit(context.Background(), b, &Event{})
+
+	if len(got) != 3 || got[2] != "second" {
+		t.Fatalf("Unexpected deliveries: %v", got)
+	}
+	if first.Active() {
+		t.Fatal("Expected cancelled subscription to be inactive")
+	}
+	if n := len(bus.Topology(b)[0].Handlers); n != 1 {
+		t.Fatalf("Expected 1 remaining handler, got %d", n)
+	}
+}
+
+func TestSubscription_ConcurrentCancel(t *testing.T) {
+	b := bus.New()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			sub := bus.Subscribe(b, func(ctx context.Context, e *Event) error { return nil })
+			sub.Cancel()
+		}()
+		go func() {
+			defer wg.Done()
+			_ = bus.Emit(context.Background(), b, &Event{})
+		}()
+	}
+	wg.Wait()
+
+	if topo := bus.Topology(b); len(topo) != 0 {
+		t.Fatalf("Expected no handlers left, got %+v", topo)
+	}
+}
+
+func TestSubscribeOnce(t *testing.T) {
+	b := bus.New()
+	calls := 0
+	fail := true
+
+	sub := bus.SubscribeOnce(b, func(ctx context.Context, e *Event) error {
+		calls++
+		if fail {
+			return errors.New("not yet")
+		}
+		return nil
+	})
+
+	_ = bus.Emit(context.Background(), b, &Event{})
+	if !sub.Active() {
+		t.Fatal("Expected subscription to survive a failure")
+	}
+
+	fail = false
+	_ = bus.Emit(context.Background(), b, &Event{})
+	_ = bus.Emit(context.Background(), b, &Event{})
+
+	if calls != 2 {
+		t.Fatalf("Expected 2 calls, got %d", calls)
+	}
+	if sub.Active() {
+		t.Fatal("Expected subscription to be removed after success")
+	}
+}
+
+func TestSubscribeOnce_Reentrant(t *testing.T) {
+	b := bus.New()
+	calls := 0
+	bus.SubscribeOnce(b, func(ctx context.Context, e *Event) error {
+		calls++
+		return bus.Emit(ctx, b, &Event{Greeting: "again"})
+	})
+
+	done := make(chan error)
+	go func() { done <- bus.Emit(context.Background(), b, &Event{}) }()
+	select {
+	case err := <-done:
+		if err != nil || calls != 1 {
+			t.Fatalf("Expected a single call, got %d, %v", calls, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Once-handler emitting its own type deadlocked")
+	}
+}
+
+func TestSubscribeOnce_Concurrent(t *testing.T) {
+	b := bus.New()
+	started, proceed := make(chan struct{}), make(chan struct{})
+	var seen []int
+	sub := bus.SubscribeOnce(b, func(ctx context.Context, e OrderCreated) error {
+		seen = append(seen, e.ID)
+		if e.ID == 1 {
+			close(started)
+			<-proceed
+			return errors.New("not yet")
+		}
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_ = bus.Emit(context.Background(), b, OrderCreated{ID: 1})
+	}()
+	<-started
+	go func() {
+		defer wg.Done()
+		_ = bus.Emit(context.Background(), b, OrderCreated{ID: 2})
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(proceed)
+	wg.Wait()
+
+	if !slices.Equal(seen, []int{1, 2}) || sub.Active() {
+		t.Fatalf("Expected the second event after the failed first, got %v, active %v", seen, sub.Active())
+	}
+}
+
+func TestSubscribeCtx(t *testing.T) {
+	b := bus.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+
+	sub := bus.SubscribeCtx(ctx, b, func(ctx context.Context, e *Event) error {
+		calls++
+		return nil
+	})
+
+	_ = bus.Emit(context.Background(), b, &Event{})
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for sub.Active() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if sub.Active() {
+		t.Fatal("Expected subscription to be cancelled with its context")
+	}
+
+	_ = bus.Emit(context.Background(), b, &Event{})
+	if calls != 1 {
+		t.Fatalf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestSubscription_Timeout(t *testing.T) {
+	b := bus.New(bus.WithStrategy(bus.BestEffort))
+	release := make(chan struct{})
+	defer close(release)
+	var ran bool
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		<-release
+		return nil
+	}, bus.WithTimeout(20*time.Millisecond), bus.PriorityHigh)
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		_, ok := ctx.Deadline()
+		ran = !ok
+		return nil
+	})
+
+	start := time.Now()
+	err := bus.Emit(context.Background(), b, &Event{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected DeadlineExceeded, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("Emit stalled on the slow handler")
+	}
+	if !ran {
+		t.Fatal("Expected the next handler to run without a deadline")
+	}
+}
+
+func TestSubscription_Filter(t *testing.T) {
+	b := bus.New()
+	var got []int
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		got = append(got, e.ID)
+		return nil
+	},
+		bus.WithFilter(func(e OrderCreated) bool { return e.ID%2 == 0 }),
+		bus.WithFilter(func(e OrderCreated) bool { return e.ID > 2 }),
+	)
+
+	for i := range 7 {
+		_ = bus.Emit(context.Background(), b, OrderCreated{ID: i})
+	}
+	if len(got) != 2 || got[0] != 4 || got[1] != 6 {
+		t.Fatalf("Unexpected deliveries: %v", got)
+	}
+}
+
+func TestSubscription_FilterReuse(t *testing.T) {
+	b := bus.New()
+	even := bus.WithFilter(func(e OrderCreated) bool { return e.ID%2 == 0 })
+	gt2 := bus.WithFilter(func(e OrderCreated) bool { return e.ID > 2 })
+	var first, second []int
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		first = append(first, e.ID)
+		return nil
+	}, gt2, even)
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		second = append(second, e.ID)
+		return nil
+	}, even)
+
+	for i := range 5 {
+		_ = bus.Emit(context.Background(), b, OrderCreated{ID: i})
+	}
+	if !slices.Equal(first, []int{4}) || !slices.Equal(second, []int{0, 2, 4}) {
+		t.Fatalf("Unexpected deliveries: %v, %v", first, second)
+	}
+}
@@ -0,0 +1,163 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+type tenantKey struct{}
+
+// ContextWithTenant attributes emissions made with ctx to tenant.
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFrom returns the tenant attributed to ctx.
+func TenantFrom(ctx context.Context) (string, bool) {
+	t, ok := ctx.Value(tenantKey{}).(string)
+	return t, ok
+}
+
+type tenants struct {
+	extract     func(ctx context.Context, event any) string
+	limit       quota
+	concurrency int
+
+	mu    sync.Mutex
+	usage map[string]*usage
+	swept time.Time
+	slots map[string]*slot
+}
+
+// slot bounds the concurrent dispatches of a tenant. It is dropped when no
+// dispatch of the tenant holds or waits for it.
+type slot struct {
+	sem   chan struct{}
+	users int
+}
+
+// admittedKey marks the context of a dispatch holding a slot of a tenant,
+// so that the emissions its handlers make for the same tenant do not wait
+// for a slot of their own.
+type admittedKey struct{}
+
+func (b *Bus) tenancy() *tenants {
+	if b.tenants == nil {
+		b.tenants = &tenants{
+			usage: map[string]*usage{},
+			slots: map[string]*slot{},
+		}
+	}
+	return b.tenants
+}
+
+// WithTenantExtractor identifies the tenant of each emission with fn
+// instead of ContextWithTenant. Emissions without a tenant are not
+// subject to tenant limits.
+func WithTenantExtractor(fn func(ctx context.Context, event any) string) Option {
+	return func(b *Bus) { b.tenancy().extract = fn }
+}
+
+// WithTenantQuota limits every tenant to limit emissions per window, so a
+// storm from one tenant cannot starve the others. Emissions over the
+// quota fail with ErrQuotaExceeded, and a QuotaExceeded event is emitted
+// for the first of them in each window.
+func WithTenantQuota(limit int, window time.Duration) Option {
+	return func(b *Bus) { b.tenancy().limit = quota{limit: limit, window: window} }
+}
+
+// WithTenantConcurrency caps the dispatches running at once for each
+// tenant at n. Dispatches over the limit wait for a slot or for their
+// context to end. Events emitted synchronously by the handlers of an
+// admitted dispatch, with its context, run within its slot.
+func WithTenantConcurrency(n int) Option {
+	return func(b *Bus) { b.tenancy().concurrency = n }
+}
+
+func (b *Bus) tenantOf(ctx context.Context, event any) string {
+	if b.tenants == nil {
+		return ""
+	}
+	if b.tenants.extract != nil {
+		return b.tenants.extract(ctx, event)
+	}
+	t, _ := TenantFrom(ctx)
+	return t
+}
+
+func (b *Bus) checkTenantQuota(ctx context.Context, env envelope) error {
+	if env.tenant == "" || b.tenants.limit.limit <= 0 {
+		return nil
+	}
+	t := b.tenants
+	limit := t.limit
+	now := time.Now()
+	t.mu.Lock()
+	if now.Sub(t.swept) >= limit.window {
+		for tenant, u := range t.usage {
+			if now.Sub(u.start) >= limit.window {
+				delete(t.usage, tenant)
+			}
+		}
+		t.swept = now
+	}
+	u := t.usage[env.tenant]
+	if u == nil || now.Sub(u.start) >= limit.window {
+		u = &usage{start: now}
+		t.usage[env.tenant] = u
+	}
+	u.count++
+	over, breach := u.count > limit.limit, u.count == limit.limit+1
+	t.mu.Unlock()
+
+	if !over {
+		return nil
+	}
+	if breach {
+		_ = dispatch(ctx, b, newEnvelope(reflect.TypeFor[QuotaExceeded](), QuotaExceeded{
+			Tenant: env.tenant,
+			Type:   env.key,
+			Limit:  limit.limit,
+			Window: limit.window,
+		}))
+	}
+	return fmt.Errorf("%w: tenant %s emitted more than %d events in %s", ErrQuotaExceeded, env.tenant, limit.limit, limit.window)
+}
+
+func (b *Bus) acquireTenant(ctx context.Context, tenant string) (context.Context, func(), error) {
+	if tenant == "" || b.tenants.concurrency <= 0 {
+		return ctx, func() {}, nil
+	}
+	if admitted, _ := ctx.Value(admittedKey{}).(string); admitted == tenant {
+		return ctx, func() {}, nil
+	}
+	t := b.tenants
+	t.mu.Lock()
+	sl, ok := t.slots[tenant]
+	if !ok {
+		sl = &slot{sem: make(chan struct{}, t.concurrency)}
+		t.slots[tenant] = sl
+	}
+	sl.users++
+	t.mu.Unlock()
+	done := func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if sl.users--; sl.users == 0 {
+			delete(t.slots, tenant)
+		}
+	}
+	select {
+	case sl.sem <- struct{}{}:
+		return context.WithValue(ctx, admittedKey{}, tenant), func() {
+			<-sl.sem
+			done()
+		}, nil
+	case <-ctx.Done():
+		done()
+		return ctx, nil, ctx.Err()
+	}
+}
@@ -0,0 +1,112 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestTenantQuota(t *testing.T) {
+	b := bus.New(bus.WithTenantQuota(2, time.Minute))
+	var exceeded []string
+	bus.Subscribe(b, func(ctx context.Context, e bus.QuotaExceeded) error {
+		exceeded = append(exceeded, e.Tenant)
+		return nil
+	})
+
+	acme := bus.ContextWithTenant(context.Background(), "acme")
+	globex := bus.ContextWithTenant(context.Background(), "globex")
+	for range 2 {
+		if err := bus.Emit(acme, b, OrderCreated{}); err != nil {
+			t.Fatalf("Unexpected error within quota: %v", err)
+		}
+	}
+	for range 2 {
+		if err := bus.Emit(acme, b, OrderCreated{}); !errors.Is(err, bus.ErrQuotaExceeded) {
+			t.Fatalf("Expected ErrQuotaExceeded, got %v", err)
+		}
+	}
+	if err := bus.Emit(globex, b, OrderCreated{}); err != nil {
+		t.Fatalf("Expected other tenants unaffected, got %v", err)
+	}
+	if err := bus.Emit(context.Background(), b, OrderCreated{}); err != nil {
+		t.Fatalf("Expected emissions without tenant unaffected, got %v", err)
+	}
+	if len(exceeded) != 1 || exceeded[0] != "acme" {
+		t.Fatalf("Unexpected QuotaExceeded events: %v", exceeded)
+	}
+}
+
+func TestTenantConcurrency(t *testing.T) {
+	b := bus.New(
+		bus.WithTenantConcurrency(1),
+		bus.WithTenantExtractor(func(ctx context.Context, event any) string {
+			if e, ok := event.(*Payment); ok {
+				return e.Customer
+			}
+			return ""
+		}),
+	)
+	running := map[string]*atomic.Int32{"acme": {}, "globex": {}}
+	var peak sync.Map
+	bus.Subscribe(b, func(ctx context.Context, e *Payment) error {
+		n := running[e.Customer].Add(1)
+		defer running[e.Customer].Add(-1)
+		if prev, _ := peak.LoadOrStore(e.Customer, n); prev.(int32) < n {
+			peak.Store(e.Customer, n)
+		}
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			customer := "acme"
+			if i%2 == 0 {
+				customer = "globex"
+			}
+			_ = bus.Emit(context.Background(), b, &Payment{Customer: customer})
+		}()
+	}
+	wg.Wait()
+
+	peak.Range(func(k, v any) bool {
+		if v.(int32) > 1 {
+			t.Errorf("Tenant %s ran %d dispatches at once", k, v)
+		}
+		return true
+	})
+}
+
+func TestTenantConcurrency_Cascade(t *testing.T) {
+	b := bus.New(bus.WithTenantConcurrency(1))
+	inner := 0
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		return bus.Emit(ctx, b, InvoiceIssued{})
+	})
+	bus.Subscribe(b, func(ctx context.Context, e InvoiceIssued) error {
+		inner++
+		return nil
+	})
+
+	done := make(chan error)
+	go func() {
+		done <- bus.Emit(bus.ContextWithTenant(context.Background(), "acme"), b, OrderCreated{})
+	}()
+	select {
+	case err := <-done:
+		if err != nil || inner != 1 {
+			t.Fatalf("Expected the nested emission delivered, got %d, %v", inner, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Nested emission waited for its own tenant slot")
+	}
+}
@@ -0,0 +1,82 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+type topicKey struct {
+	typ   reflect.Type
+	topic string
+}
+
+// SubscribeTopic is like Subscribe but only receives the events of type T
+// emitted on topic with EmitTopic. Handlers subscribed with Subscribe do
+// not receive topic events.
+//
+// Topics are dot-separated, and topic may be a pattern: "orders.*" matches
+// "orders.created" but not "orders.eu.created", while "orders.>" matches
+// both. Malformed patterns are reported by Subscription.Err.
+func SubscribeTopic[T any](b *Bus, topic string, fn Handler[T], opts ...SubscribeOption) *Subscription {
+	if b == nil {
+		b = defaultBus
+	}
+	if topic == "" {
+		return Subscribe(b, fn, opts...)
+	}
+	sub := newSubscriber(fn, opts)
+	sub.seq = b.seq.Add(1)
+	s := &Subscription{bus: b, key: sub.key, topic: topic, sub: sub}
+	if b.inflight.isClosed() {
+		sub.cancelled.Store(true)
+		s.err = ErrClosed
+		return s
+	}
+	if err := b.checkDependencies(sub); err != nil {
+		sub.cancelled.Store(true)
+		s.err = err
+		return s
+	}
+	if err := b.checkDeprecated(sub.key); err != nil {
+		sub.cancelled.Store(true)
+		s.err = err
+		return s
+	}
+	if isPattern(topic) {
+		if !validPattern(topic) {
+			sub.cancelled.Store(true)
+			s.err = fmt.Errorf("%w: %q", ErrInvalidTopic, topic)
+			return s
+		}
+		b.patterns.add(sub.key, topic, sub)
+		return s
+	}
+	b.topics.Compute(topicKey{sub.key, topic}, insert(sub))
+	return s
+}
+
+// EmitTopic is like Emit but delivers event to the handlers subscribed to
+// topic. An empty topic is the same as Emit.
+func EmitTopic[T any](ctx context.Context, b *Bus, topic string, event T) error {
+	if b == nil {
+		b = defaultBus
+	}
+	env := newEnvelope(reflect.TypeFor[T](), event)
+	env.topic = topic
+	return b.send(ctx, env)
+}
+
+// EmitTopicAny is like EmitTopic but keys event by its dynamic type, as
+// EmitAny does.
+func EmitTopicAny(ctx context.Context, b *Bus, topic string, event any) error {
+	if b == nil {
+		b = defaultBus
+	}
+	if event == nil {
+		return ErrNilEvent
+	}
+	env := newEnvelope(reflect.TypeOf(event), event)
+	env.topic = topic
+	return b.send(ctx, env)
+}
@@ -0,0 +1,90 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestTopics(t *testing.T) {
+	b := bus.New()
+	var created, cancelled, untyped []string
+	subCreated := bus.SubscribeTopic(b, "orders.created", func(ctx context.Context, e OrderCreated) error {
+		d, _ := bus.DeliveryFrom(ctx)
+		created = append(created, d.Topic)
+		return nil
+	})
+	bus.SubscribeTopic(b, "orders.cancelled", func(ctx context.Context, e OrderCreated) error {
+		cancelled = append(cancelled, "x")
+		return nil
+	})
+	bus.Subscribe(b, func(ctx context.Context, e OrderCreated) error {
+		untyped = append(untyped, "x")
+		return nil
+	})
+
+	ctx := context.Background()
+	_ = bus.EmitTopic(ctx, b, "orders.created", OrderCreated{})
+	_ = bus.EmitTopic(ctx, b, "orders.created", OrderCreated{})
+	_ = bus.EmitTopic(ctx, b, "orders.cancelled", OrderCreated{})
+	_ = bus.Emit(ctx, b, OrderCreated{})
+
+	if len(created) != 2 || created[0] != "orders.created" {
+		t.Fatalf("Unexpected created deliveries: %v", created)
+	}
+	if len(cancelled) != 1 || len(untyped) != 1 {
+		t.Fatalf("Unexpected deliveries: cancelled=%v untyped=%v", cancelled, untyped)
+	}
+
+	subCreated.Cancel()
+	_ = bus.EmitTopic(ctx, b, "orders.created", OrderCreated{})
+	if len(created) != 2 {
+		t.Fatalf("Expected no deliveries after Cancel, got %v", created)
+	}
+}
+
+func TestTopicPatterns(t *testing.T) {
+	b := bus.New()
+	var got []string
+	record := func(name string) bus.Handler[OrderCreated] {
+		return func(ctx context.Context, e OrderCreated) error {
+			d, _ := bus.DeliveryFrom(ctx)
+			got = append(got, name+":"+d.Topic)
+			return nil
+		}
+	}
+	bus.SubscribeTopic(b, "orders.*", record("star"))
+	tail := bus.SubscribeTopic(b, "orders.>", record("tail"), bus.PriorityHigh)
+	bus.SubscribeTopic(b, "orders.created", record("exact"))
+	bus.SubscribeTopic(b, "*.created", record("any-created"), bus.PriorityLow)
+
+	ctx := context.Background()
+	_ = bus.EmitTopic(ctx, b, "orders.created", OrderCreated{})
+	want := []string{"tail:orders.created", "star:orders.created", "exact:orders.created", "any-created:orders.created"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Got %v, want %v", got, want)
+	}
+
+	got = nil
+	_ = bus.EmitTopic(ctx, b, "orders.eu.created", OrderCreated{})
+	_ = bus.EmitTopic(ctx, b, "orders", OrderCreated{})
+	if !slices.Equal(got, []string{"tail:orders.eu.created"}) {
+		t.Fatalf("Unexpected deliveries: %v", got)
+	}
+
+	tail.Cancel()
+	got = nil
+	_ = bus.EmitTopic(ctx, b, "orders.eu.created", OrderCreated{})
+	if len(got) != 0 {
+		t.Fatalf("Expected no deliveries after Cancel, got %v", got)
+	}
+
+	for _, pattern := range []string{"orders.>.created", "orders..*", "orders.a*"} {
+		if err := bus.SubscribeTopic(b, pattern, record("bad")).Err(); !errors.Is(err, bus.ErrInvalidTopic) {
+			t.Errorf("%q: expected ErrInvalidTopic, got %v", pattern, err)
+		}
+	}
+}
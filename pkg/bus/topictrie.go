@@ -0,0 +1,136 @@
+package bus
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidTopic is reported by Subscription.Err for malformed topic
+// patterns.
+var ErrInvalidTopic = errors.New("bus: invalid topic pattern")
+
+// topicTrie indexes topic patterns by dot-separated segment. A "*"
+// segment matches exactly one segment and a trailing ">" matches one or
+// more.
+type topicTrie struct {
+	mu   sync.RWMutex
+	root topicNode
+}
+
+type topicNode struct {
+	children map[string]*topicNode
+	subs     map[reflect.Type][]*subscriber
+}
+
+func isPattern(topic string) bool {
+	return strings.Contains(topic, "*") || strings.Contains(topic, ">")
+}
+
+func validPattern(pattern string) bool {
+	segs := strings.Split(pattern, ".")
+	for i, seg := range segs {
+		switch {
+		case seg == "":
+			return false
+		case seg == ">" && i != len(segs)-1:
+			return false
+		case seg != "*" && seg != ">" && isPattern(seg):
+			return false
+		}
+	}
+	return true
+}
+
+func (t *topicTrie) add(key reflect.Type, pattern string, s *subscriber) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := &t.root
+	for _, seg := range strings.Split(pattern, ".") {
+		if n.children == nil {
+			n.children = map[string]*topicNode{}
+		}
+		child, ok := n.children[seg]
+		if !ok {
+			child = &topicNode{}
+			n.children[seg] = child
+		}
+		n = child
+	}
+	if n.subs == nil {
+		n.subs = map[reflect.Type][]*subscriber{}
+	}
+	n.subs[key] = insert(s)(n.subs[key], true)
+}
+
+func (t *topicTrie) remove(key reflect.Type, pattern string, s *subscriber) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := &t.root
+	for _, seg := range strings.Split(pattern, ".") {
+		if n = n.children[seg]; n == nil {
+			return
+		}
+	}
+	n.subs[key] = without(s)(n.subs[key], true)
+}
+
+// all returns the subscribers of every pattern.
+func (t *topicTrie) all() []*subscriber {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var out []*subscriber
+	var walk func(n *topicNode)
+	walk = func(n *topicNode) {
+		for _, subs := range n.subs {
+			out = append(out, subs...)
+		}
+		for _, child := range n.children {
+			walk(child)
+		}
+	}
+	walk(&t.root)
+	return out
+}
+
+// match returns the subscribers of key whose pattern matches topic.
+func (t *topicTrie) match(key reflect.Type, topic string) [][]*subscriber {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if len(t.root.children) == 0 {
+		return nil
+	}
+	var out [][]*subscriber
+	segs := strings.Split(topic, ".")
+	var walk func(n *topicNode, i int)
+	walk = func(n *topicNode, i int) {
+		if i == len(segs) {
+			if subs := n.subs[key]; len(subs) > 0 {
+				out = append(out, subs)
+			}
+			return
+		}
+		if child := n.children[segs[i]]; child != nil {
+			walk(child, i+1)
+		}
+		if child := n.children["*"]; child != nil {
+			walk(child, i+1)
+		}
+		if child := n.children[">"]; child != nil {
+			if subs := child.subs[key]; len(subs) > 0 {
+				out = append(out, subs)
+			}
+		}
+	}
+	walk(&t.root, 0)
+	return out
+}
+
+// merge combines subscriber lists into a single one in dispatch order.
+func merge(lists ...[]*subscriber) []*subscriber {
+	all := slices.Concat(lists...)
+	_ = arrange(all)
+	return all
+}
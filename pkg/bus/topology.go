@@ -0,0 +1,83 @@
+package bus
+
+import (
+	"reflect"
+	"runtime"
+	"sort"
+)
+
+// HandlerInfo describes a registered handler.
+type HandlerInfo struct {
+	Type     reflect.Type
+	Name     string
+	Priority Priority
+}
+
+// TypeTopology describes the wiring of a single event type.
+type TypeTopology struct {
+	Type       reflect.Type
+	Producer   string
+	Deprecated string
+	Handlers   []HandlerInfo
+}
+
+// Topology returns the registered event types sorted by name, each with its
+// handlers, topic ones and responders included, in dispatch order.
+// Wildcard handlers are not listed.
+func Topology(b *Bus) []TypeTopology {
+	if b == nil {
+		b = defaultBus
+	}
+	byType := map[reflect.Type]*TypeTopology{}
+	get := func(t reflect.Type) *TypeTopology {
+		if tt, ok := byType[t]; ok {
+			return tt
+		}
+		tt := &TypeTopology{Type: t}
+		byType[t] = tt
+		return tt
+	}
+
+	for _, sub := range b.ordered() {
+		if sub.key != nil {
+			tt := get(sub.key)
+			tt.Handlers = append(tt.Handlers, sub.info())
+		}
+	}
+	b.producers.Range(func(t reflect.Type, name string) bool {
+		get(t).Producer = name
+		return true
+	})
+	b.deprecated.Range(func(t reflect.Type, reason string) bool {
+		get(t).Deprecated = reason
+		return true
+	})
+
+	out := make([]TypeTopology, 0, len(byType))
+	for _, tt := range byType {
+		out = append(out, *tt)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Type.String() < out[j].Type.String()
+	})
+	return out
+}
+
+func (s *subscriber) info() HandlerInfo {
+	name := s.name
+	if name == "" {
+		name = funcName(s.handler)
+	}
+	return HandlerInfo{Type: s.key, Name: name, Priority: s.priority}
+}
+
+func funcName(fn any) string {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return ""
+	}
+	if f := runtime.FuncForPC(v.Pointer()); f != nil {
+		return f.Name()
+	}
+	return ""
+}
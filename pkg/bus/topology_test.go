@@ -0,0 +1,40 @@
+package bus_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestTopology(t *testing.T) {
+	b := bus.New()
+	bus.DeclareProducer[InvoiceIssued](b, "billing-service")
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error { return nil }, bus.PriorityLow)
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error { return nil }, bus.PriorityHigh)
+
+	topo := bus.Topology(b)
+	if len(topo) != 2 {
+		t.Fatalf("Expected 2 types, got %d", len(topo))
+	}
+
+	for _, tt := range topo {
+		switch tt.Type {
+		case reflect.TypeFor[InvoiceIssued]():
+			if tt.Producer != "billing-service" || len(tt.Handlers) != 0 {
+				t.Fatalf("Unexpected topology for InvoiceIssued: %+v", tt)
+			}
+		case reflect.TypeFor[*Event]():
+			if len(tt.Handlers) != 2 || tt.Handlers[0].Priority != bus.PriorityHigh {
+				t.Fatalf("Unexpected handlers for *Event: %+v", tt.Handlers)
+			}
+			if !strings.Contains(tt.Handlers[0].Name, "TestTopology") {
+				t.Fatalf("Unexpected handler name %q", tt.Handlers[0].Name)
+			}
+		default:
+			t.Fatalf("Unexpected type %s", tt.Type)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package bus
+
+import (
+	"context"
+	"reflect"
+	"runtime/trace"
+)
+
+// WithTracing records every dispatch as a runtime/trace task, annotated
+// with the event type, and every handler invocation as a region named
+// after the handler, so that `go tool trace` shows the dispatch structure.
+// Nothing is recorded unless an execution trace is being collected.
+func WithTracing() Option {
+	return func(b *Bus) { b.tracing = true }
+}
+
+func (b *Bus) traceDispatch(ctx context.Context, key reflect.Type) (context.Context, func()) {
+	if !b.tracing || !trace.IsEnabled() {
+		return ctx, func() {}
+	}
+	ctx, task := trace.NewTask(ctx, "bus.Emit")
+	trace.Log(ctx, "type", key.String())
+	return ctx, task.End
+}
+
+func (b *Bus) traceHandler(ctx context.Context, s *subscriber) func() {
+	if !b.tracing || !trace.IsEnabled() {
+		return func() {}
+	}
+	return trace.StartRegion(ctx, s.info().Name).End
+}
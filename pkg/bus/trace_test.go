@@ -0,0 +1,32 @@
+package bus_test
+
+import (
+	"bytes"
+	"context"
+	"runtime/trace"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestTracing(t *testing.T) {
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Skipf("Tracing unavailable: %v", err)
+	}
+	b := bus.New(bus.WithTracing())
+	var calls int
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		calls++
+		return nil
+	}, bus.WithName("traced-handler"))
+	err := bus.Emit(context.Background(), b, &Event{})
+	trace.Stop()
+
+	if err != nil || calls != 1 {
+		t.Fatalf("Unexpected dispatch: calls=%d err=%v", calls, err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("traced-handler")) {
+		t.Fatal("Expected handler region in trace")
+	}
+}
@@ -0,0 +1,76 @@
+package bus
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrSignature is returned by the webhook verifiers for requests whose
+// signature is missing, malformed or wrong.
+var ErrSignature = errors.New("bus: invalid webhook signature")
+
+// HMACSHA256 verifies webhooks carrying the hex HMAC-SHA256 of their body,
+// keyed with secret, in header after prefix.
+func HMACSHA256(header, prefix string, secret []byte) Verifier {
+	return func(r *http.Request, body []byte) error {
+		sig, ok := strings.CutPrefix(r.Header.Get(header), prefix)
+		if !ok || !validMAC(secret, body, sig) {
+			return ErrSignature
+		}
+		return nil
+	}
+}
+
+// GitHubSignature verifies the X-Hub-Signature-256 header of GitHub
+// webhooks.
+func GitHubSignature(secret []byte) Verifier {
+	return HMACSHA256("X-Hub-Signature-256", "sha256=", secret)
+}
+
+// StripeSignature verifies the Stripe-Signature header of Stripe webhooks,
+// rejecting signatures older than tolerance to prevent replays.
+func StripeSignature(secret []byte, tolerance time.Duration) Verifier {
+	return func(r *http.Request, body []byte) error {
+		var ts string
+		var sigs []string
+		for _, part := range strings.Split(r.Header.Get("Stripe-Signature"), ",") {
+			k, v, _ := strings.Cut(part, "=")
+			switch k {
+			case "t":
+				ts = v
+			case "v1":
+				sigs = append(sigs, v)
+			}
+		}
+		sec, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			return ErrSignature
+		}
+		if tolerance > 0 && time.Since(time.Unix(sec, 0)) > tolerance {
+			return ErrSignature
+		}
+		payload := append([]byte(ts+"."), body...)
+		for _, sig := range sigs {
+			if validMAC(secret, payload, sig) {
+				return nil
+			}
+		}
+		return ErrSignature
+	}
+}
+
+func validMAC(secret, payload []byte, sig string) bool {
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hmac.Equal(got, mac.Sum(nil))
+}
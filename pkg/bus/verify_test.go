@@ -0,0 +1,66 @@
+package bus_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func sign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestGitHubSignature(t *testing.T) {
+	verify := bus.GitHubSignature([]byte("s3cret"))
+	body := []byte(`{"ref":"main"}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("X-Hub-Signature-256", "sha256="+sign("s3cret", string(body)))
+	if err := verify(req, body); err != nil {
+		t.Fatalf("Expected valid signature, got %v", err)
+	}
+	req.Header.Set("X-Hub-Signature-256", "sha256="+sign("other", string(body)))
+	if err := verify(req, body); !errors.Is(err, bus.ErrSignature) {
+		t.Fatalf("Expected ErrSignature, got %v", err)
+	}
+	req.Header.Del("X-Hub-Signature-256")
+	if err := verify(req, body); !errors.Is(err, bus.ErrSignature) {
+		t.Fatalf("Expected ErrSignature without header, got %v", err)
+	}
+}
+
+func TestStripeSignature(t *testing.T) {
+	verify := bus.StripeSignature([]byte("whsec"), 5*time.Minute)
+	body := []byte(`{"type":"charge.succeeded"}`)
+	header := func(at time.Time, secret string) string {
+		ts := strconv.FormatInt(at.Unix(), 10)
+		return "t=" + ts + ",v1=" + sign(secret, ts+"."+string(body))
+	}
+
+	tests := []struct {
+		header string
+		ok     bool
+	}{
+		{header(time.Now(), "whsec"), true},
+		{header(time.Now(), "other"), false},
+		{header(time.Now().Add(-time.Hour), "whsec"), false},
+		{"v1=deadbeef", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("Stripe-Signature", tt.header)
+		if err := verify(req, body); (err == nil) != tt.ok {
+			t.Errorf("%s: got %v, want ok=%v", tt.header, err, tt.ok)
+		}
+	}
+}
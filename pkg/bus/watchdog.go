@@ -0,0 +1,106 @@
+package bus
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// InFlightDispatch describes an asynchronous dispatch that has not
+// completed yet.
+type InFlightDispatch struct {
+	Type  reflect.Type
+	Since time.Time
+}
+
+// StuckDispatch is emitted on the bus by the watchdog, once, for every
+// async dispatch running for longer than the configured maximum age.
+type StuckDispatch struct {
+	Type reflect.Type
+	Age  time.Duration
+}
+
+type tracker struct {
+	mu       sync.Mutex
+	nextID   uint64
+	active   map[uint64]InFlightDispatch
+	reported map[uint64]bool
+}
+
+func (t *tracker) add(key reflect.Type) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.active == nil {
+		t.active = map[uint64]InFlightDispatch{}
+	}
+	t.nextID++
+	t.active[t.nextID] = InFlightDispatch{Type: key, Since: time.Now()}
+	return t.nextID
+}
+
+func (t *tracker) remove(id uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.active, id)
+	delete(t.reported, id)
+}
+
+// stuck returns the dispatches older than maxAge at now that have not
+// been returned before.
+func (t *tracker) stuck(now time.Time, maxAge time.Duration) []StuckDispatch {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var out []StuckDispatch
+	for id, d := range t.active {
+		if age := now.Sub(d.Since); age > maxAge && !t.reported[id] {
+			if t.reported == nil {
+				t.reported = map[uint64]bool{}
+			}
+			t.reported[id] = true
+			out = append(out, StuckDispatch{Type: d.Type, Age: age})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Age > out[j].Age })
+	return out
+}
+
+// InFlight returns the asynchronous dispatches currently running, oldest
+// first.
+func (b *Bus) InFlight() []InFlightDispatch {
+	b.tracker.mu.Lock()
+	out := make([]InFlightDispatch, 0, len(b.tracker.active))
+	for _, d := range b.tracker.active {
+		out = append(out, d)
+	}
+	b.tracker.mu.Unlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].Since.Before(out[j].Since) })
+	return out
+}
+
+// WithWatchdog checks in-flight async dispatches every interval and emits a
+// StuckDispatch event for those older than maxAge. The watchdog stops when
+// the bus is closed.
+func WithWatchdog(maxAge, interval time.Duration) Option {
+	return func(b *Bus) {
+		b.watchdogAge = maxAge
+		b.watchdogTick = interval
+	}
+}
+
+func (b *Bus) watch() {
+	defer b.background.Done()
+	ticker := time.NewTicker(b.watchdogTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.done:
+			return
+		case now := <-ticker.C:
+			for _, d := range b.tracker.stuck(now, b.watchdogAge) {
+				_ = b.send(context.Background(), newEnvelope(reflect.TypeFor[StuckDispatch](), d))
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package bus_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestWatchdog_ReportsStuckDispatch(t *testing.T) {
+	b := bus.New(bus.WithWatchdog(10*time.Millisecond, 5*time.Millisecond))
+	stuck := make(chan bus.StuckDispatch, 1)
+	release := make(chan struct{})
+	var reports atomic.Int32
+
+	bus.Subscribe(b, func(ctx context.Context, e bus.StuckDispatch) error {
+		reports.Add(1)
+		select {
+		case stuck <- e:
+		default:
+		}
+		return nil
+	})
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error {
+		<-release
+		return nil
+	})
+
+	bus.EmitAsync(context.Background(), b, &Event{})
+
+	select {
+	case s := <-stuck:
+		if s.Age < 10*time.Millisecond {
+			t.Fatalf("Unexpected age %s", s.Age)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for StuckDispatch")
+	}
+	if n := len(b.InFlight()); n != 1 {
+		t.Fatalf("Expected 1 in-flight dispatch, got %d", n)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if n := reports.Load(); n != 1 {
+		t.Fatalf("Expected the stuck dispatch reported once, got %d reports", n)
+	}
+
+	close(release)
+	if err := b.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if n := len(b.InFlight()); n != 0 {
+		t.Fatalf("Expected no in-flight dispatch, got %d", n)
+	}
+}
@@ -0,0 +1,33 @@
+package bus
+
+import (
+	"context"
+	"hash/fnv"
+)
+
+// Weighted returns a handler that routes percent% of deliveries to next and
+// the rest to current. Deliveries sharing a key are always routed to the
+// same handler. Without a key function deliveries are keyed by the Hash of
+// the event on b, so equal events take the same route; events that cannot
+// be hashed go to current.
+func Weighted[T any](b *Bus, current, next Handler[T], percent int, key func(T) string) Handler[T] {
+	return func(ctx context.Context, event T) error {
+		if bucket(b, event, key) < percent {
+			return next(ctx, event)
+		}
+		return current(ctx, event)
+	}
+}
+
+func bucket[T any](b *Bus, event T, key func(T) string) int {
+	if key == nil {
+		h, err := Hash(b, event)
+		if err != nil {
+			return 100
+		}
+		return int(h % 100)
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key(event)))
+	return int(h.Sum32() % 100)
+}
@@ -0,0 +1,67 @@
+package bus_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+type Payment struct {
+	Customer string
+}
+
+func TestWeighted_ConsistentByKey(t *testing.T) {
+	b := bus.New()
+	routed := map[string]string{}
+	current, next := 0, 0
+
+	bus.Subscribe(b, bus.Weighted(b,
+		func(ctx context.Context, e Payment) error {
+			current++
+			routed[e.Customer] += "c"
+			return nil
+		},
+		func(ctx context.Context, e Payment) error {
+			next++
+			routed[e.Customer] += "n"
+			return nil
+		},
+		10,
+		func(e Payment) string { return e.Customer },
+	))
+
+	for round := 0; round < 2; round++ {
+		for i := 0; i < 1000; i++ {
+			_ = bus.Emit(context.Background(), b, Payment{Customer: fmt.Sprintf("c-%d", i)})
+		}
+	}
+
+	for customer, path := range routed {
+		if path != "cc" && path != "nn" {
+			t.Fatalf("Customer %s routed inconsistently: %s", customer, path)
+		}
+	}
+	if next == 0 || next > current {
+		t.Fatalf("Unexpected split: current=%d next=%d", current, next)
+	}
+}
+
+func TestWeighted_Bounds(t *testing.T) {
+	calls := 0
+	h := bus.Weighted(nil,
+		func(ctx context.Context, e Payment) error { return nil },
+		func(ctx context.Context, e Payment) error {
+			calls++
+			return nil
+		},
+		0, nil,
+	)
+	for i := 0; i < 100; i++ {
+		_ = h(context.Background(), Payment{})
+	}
+	if calls != 0 {
+		t.Fatalf("Expected no deliveries at 0%%, got %d", calls)
+	}
+}
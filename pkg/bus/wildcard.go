@@ -0,0 +1,72 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// SubscribeAll registers fn for every event emitted on b, whatever its
+// type, after the handlers of that type have run, whether they succeeded
+// or not. Bus middlewares apply to it as they do to typed handlers.
+func SubscribeAll(b *Bus, fn func(ctx context.Context, event any) error, opts ...SubscribeOption) *Subscription {
+	if b == nil {
+		b = defaultBus
+	}
+	sub := &subscriber{handler: fn, call: fn, priority: PriorityNormal, subscribed: time.Now()}
+	for _, opt := range opts {
+		opt.applySubscribe(sub)
+	}
+	sub.seq = b.seq.Add(1)
+	if b.inflight.isClosed() {
+		sub.cancelled.Store(true)
+		return &Subscription{bus: b, sub: sub, err: ErrClosed}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.wildcard = append(b.wildcard[:len(b.wildcard):len(b.wildcard)], sub)
+	return &Subscription{bus: b, sub: sub}
+}
+
+// SubscribeWildcard is like SubscribeAll, without a handle to cancel the
+// subscription.
+func SubscribeWildcard(b *Bus, fn func(ctx context.Context, event any) error) {
+	SubscribeAll(b, fn)
+}
+
+func (b *Bus) removeWildcard(s *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := make([]*subscriber, 0, len(b.wildcard))
+	for _, w := range b.wildcard {
+		if w != s {
+			subs = append(subs, w)
+		}
+	}
+	b.wildcard = subs
+}
+
+// deliverAll runs every wildcard handler, whatever the outcome of the
+// typed handlers, reporting their failures as the typed ones are.
+func (b *Bus) deliverAll(ctx context.Context, event any, env envelope) error {
+	b.mu.RLock()
+	wildcards := b.wildcard
+	b.mu.RUnlock()
+	var errs []error
+	for _, w := range wildcards {
+		err := b.deliver(ctx, w, event, env)
+		if err == nil {
+			continue
+		}
+		b.fail(ctx, w, DispatchError{
+			Handler:  w.info(),
+			Type:     env.key,
+			Event:    event,
+			Priority: w.priority,
+			Async:    env.async,
+			Err:      err,
+		})
+		errs = append(errs, &HandlerError{Name: w.info().Name, Type: env.key, Err: err})
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,58 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+func TestSubscribeAll(t *testing.T) {
+	b := bus.New()
+	var seen []any
+	sub := bus.SubscribeAll(b, func(ctx context.Context, event any) error {
+		seen = append(seen, event)
+		return nil
+	})
+	b.Use(func(ctx context.Context, event any, next func(ctx context.Context, event any) error) error {
+		return next(ctx, event)
+	})
+
+	ctx := context.Background()
+	_ = bus.Emit(ctx, b, &Event{Greeting: "hi"})
+	_ = bus.Emit(ctx, b, InvoiceIssued{})
+	if len(seen) != 2 {
+		t.Fatalf("Expected 2 events with middleware installed, got %v", seen)
+	}
+
+	sub.Cancel()
+	_ = bus.Emit(ctx, b, &Event{})
+	if len(seen) != 2 || sub.Active() {
+		t.Fatalf("Expected no deliveries after Cancel, got %v", seen)
+	}
+}
+
+func TestSubscribeAll_AfterFailure(t *testing.T) {
+	var dead []bus.DispatchError
+	b := bus.New(bus.WithStrategy(bus.BestEffort), bus.WithDeadLetter(func(ctx context.Context, failed bus.DispatchError) {
+		dead = append(dead, failed)
+	}))
+	errs := bus.Errors(b)
+	seen := 0
+	bus.Subscribe(b, func(ctx context.Context, e *Event) error { return errors.New("typed") })
+	bus.SubscribeAll(b, func(ctx context.Context, event any) error {
+		seen++
+		return nil
+	})
+	tap := errors.New("tap")
+	bus.SubscribeAll(b, func(ctx context.Context, event any) error { return tap })
+
+	err := bus.Emit(context.Background(), b, &Event{})
+	if seen != 1 {
+		t.Fatalf("Expected the wildcard handler to run after a typed failure, got %d deliveries", seen)
+	}
+	if !errors.Is(err, tap) || len(dead) != 2 || len(errs) != 2 {
+		t.Fatalf("Expected wildcard failures reported, got %v, %d dead letters, %d errors", err, len(dead), len(errs))
+	}
+}
@@ -0,0 +1,114 @@
+package bustest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+	"github.com/mirkobrombin/go-signal/v2/pkg/wire"
+)
+
+// Codec is the wire encoding events are verified against.
+type Codec = wire.Codec
+
+// JSONCodec encodes events with encoding/json.
+type JSONCodec = wire.JSONCodec
+
+// Contract collects the event types producers emit, with samples, and the
+// types consumers expect, so services sharing event definitions can verify
+// they still agree.
+type Contract struct {
+	mu        sync.Mutex
+	samples   map[reflect.Type][]any
+	producers map[reflect.Type][]string
+	consumers map[reflect.Type][]string
+}
+
+func NewContract() *Contract {
+	return &Contract{
+		samples:   map[reflect.Type][]any{},
+		producers: map[reflect.Type][]string{},
+		consumers: map[reflect.Type][]string{},
+	}
+}
+
+// Produces declares that producer emits T, with representative samples.
+func Produces[T any](c *Contract, producer string, samples ...T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := reflect.TypeFor[T]()
+	c.producers[key] = append(c.producers[key], producer)
+	for _, s := range samples {
+		c.samples[key] = append(c.samples[key], s)
+	}
+}
+
+// Consumes declares that consumer handles T.
+func Consumes[T any](c *Contract, consumer string) {
+	c.consume(reflect.TypeFor[T](), consumer)
+}
+
+// ConsumesFrom declares every type subscribed on b as consumed by consumer.
+func (c *Contract) ConsumesFrom(consumer string, b *bus.Bus) {
+	for _, tt := range bus.Topology(b) {
+		if len(tt.Handlers) > 0 {
+			c.consume(tt.Type, consumer)
+		}
+	}
+}
+
+func (c *Contract) consume(key reflect.Type, consumer string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.consumers[key] = append(c.consumers[key], consumer)
+}
+
+// Verify checks that every consumed type has a producer with samples and
+// that every sample survives a round-trip through codec unchanged.
+func (c *Contract) Verify(t testing.TB, codec Codec) {
+	t.Helper()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range sortedTypes(c.consumers) {
+		if len(c.producers[key]) == 0 {
+			t.Errorf("bustest: %s consumed by %v has no producer", key, c.consumers[key])
+		} else if len(c.samples[key]) == 0 {
+			t.Errorf("bustest: %s produced by %v has no samples", key, c.producers[key])
+		}
+	}
+	for _, key := range sortedTypes(c.samples) {
+		for i, sample := range c.samples[key] {
+			if err := roundTrip(codec, key, sample); err != nil {
+				t.Errorf("bustest: %s sample %d: %v", key, i, err)
+			}
+		}
+	}
+}
+
+func roundTrip(codec Codec, key reflect.Type, sample any) error {
+	data, err := codec.Marshal(sample)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	out := reflect.New(key)
+	if err := codec.Unmarshal(data, out.Interface()); err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+	if got := out.Elem().Interface(); !reflect.DeepEqual(got, sample) {
+		return fmt.Errorf("round-trip mismatch: got %#v, want %#v", got, sample)
+	}
+	return nil
+}
+
+func sortedTypes[V any](m map[reflect.Type]V) []reflect.Type {
+	keys := make([]reflect.Type, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
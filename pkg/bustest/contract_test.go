@@ -0,0 +1,52 @@
+package bustest_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+	"github.com/mirkobrombin/go-signal/v2/pkg/bustest"
+)
+
+type Lossy struct {
+	At     time.Time
+	secret string
+}
+
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, format)
+}
+
+func TestContract_Verify(t *testing.T) {
+	c := bustest.NewContract()
+	bustest.Produces(c, "workflow", Step{N: 1}, Step{N: 2})
+
+	b := bus.New()
+	bus.Subscribe(b, func(ctx context.Context, e Step) error { return nil })
+	c.ConsumesFrom("reporting", b)
+
+	c.Verify(t, bustest.JSONCodec{})
+}
+
+func TestContract_Drift(t *testing.T) {
+	c := bustest.NewContract()
+	bustest.Consumes[Shipped](c, "notifications")
+	bustest.Produces(c, "vault", Lossy{secret: "dropped by JSON"})
+
+	rt := &recordingT{TB: t}
+	c.Verify(rt, bustest.JSONCodec{})
+
+	joined := strings.Join(rt.errors, "\n")
+	if len(rt.errors) != 2 || !strings.Contains(joined, "has no producer") {
+		t.Fatalf("Expected missing producer and round-trip errors, got %v", rt.errors)
+	}
+}
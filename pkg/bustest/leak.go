@@ -0,0 +1,23 @@
+package bustest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+	"go.uber.org/goleak"
+)
+
+// VerifyNoLeaks closes b and asserts that no goroutines are left running
+// afterwards. It is meant to be deferred at the top of a test.
+func VerifyNoLeaks(t testing.TB, b *bus.Bus, opts ...goleak.Option) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	if err := b.Close(ctx); err != nil {
+		t.Fatalf("bustest: closing bus: %v", err)
+	}
+	if err := goleak.Find(opts...); err != nil {
+		t.Fatalf("bustest: %v", err)
+	}
+}
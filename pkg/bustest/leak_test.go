@@ -0,0 +1,23 @@
+package bustest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+	"github.com/mirkobrombin/go-signal/v2/pkg/bustest"
+)
+
+func TestVerifyNoLeaks(t *testing.T) {
+	b := bus.New()
+	defer bustest.VerifyNoLeaks(t, b)
+
+	bus.Subscribe(b, func(ctx context.Context, e Step) error {
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+	for i := 0; i < 10; i++ {
+		bus.EmitAsync(context.Background(), b, Step{N: i})
+	}
+}
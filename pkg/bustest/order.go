@@ -0,0 +1,79 @@
+package bustest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Timeout bounds how long the assertions wait for asynchronously
+// delivered events to show up in the recorder.
+var Timeout = time.Second
+
+// AssertOrder asserts that events were recorded in the given relative
+// order. Other events may be interleaved, so concurrent emissions that are
+// not part of the assertion do not make it flaky.
+func AssertOrder(t testing.TB, rec *Recorder, events ...any) {
+	t.Helper()
+	if !eventually(func() bool { return isSubsequence(rec.Events(), events) }) {
+		t.Fatalf("bustest: events not recorded in order\nwant: %v\ngot:  %v", events, rec.Events())
+	}
+}
+
+// AssertBefore asserts that before was recorded earlier than after,
+// without constraining anything else.
+func AssertBefore(t testing.TB, rec *Recorder, before, after any) {
+	t.Helper()
+	AssertOrder(t, rec, before, after)
+}
+
+// AssertContains asserts that all events were recorded, in any order.
+func AssertContains(t testing.TB, rec *Recorder, events ...any) {
+	t.Helper()
+	ok := eventually(func() bool {
+		got := rec.Events()
+		for _, want := range events {
+			if indexOf(got, want, 0) < 0 {
+				return false
+			}
+		}
+		return true
+	})
+	if !ok {
+		t.Fatalf("bustest: events not recorded\nwant: %v\ngot:  %v", events, rec.Events())
+	}
+}
+
+func eventually(cond func() bool) bool {
+	deadline := time.Now().Add(Timeout)
+	for {
+		if cond() {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func isSubsequence(got, want []any) bool {
+	from := 0
+	for _, w := range want {
+		i := indexOf(got, w, from)
+		if i < 0 {
+			return false
+		}
+		from = i + 1
+	}
+	return true
+}
+
+func indexOf(events []any, want any, from int) int {
+	for i := from; i < len(events); i++ {
+		if reflect.DeepEqual(events[i], want) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,70 @@
+package bustest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+	"github.com/mirkobrombin/go-signal/v2/pkg/bustest"
+)
+
+type Step struct {
+	N int
+}
+
+func TestAssertOrder(t *testing.T) {
+	b := bus.New()
+	rec := bustest.Record(b)
+
+	for i := 1; i <= 5; i++ {
+		_ = bus.Emit(context.Background(), b, Step{N: i})
+	}
+
+	bustest.AssertOrder(t, rec, Step{N: 1}, Step{N: 3}, Step{N: 5})
+	bustest.AssertBefore(t, rec, Step{N: 2}, Step{N: 4})
+	bustest.AssertContains(t, rec, Step{N: 5}, Step{N: 1})
+}
+
+func TestAssertOrder_Async(t *testing.T) {
+	b := bus.New()
+	rec := bustest.Record(b)
+
+	bus.EmitAsync(context.Background(), b, Step{N: 1})
+	bus.EmitAsync(context.Background(), b, Step{N: 2})
+
+	bustest.AssertContains(t, rec, Step{N: 1}, Step{N: 2})
+}
+
+func TestAssertOrder_Fails(t *testing.T) {
+	b := bus.New()
+	rec := bustest.Record(b)
+	_ = bus.Emit(context.Background(), b, Step{N: 2})
+	_ = bus.Emit(context.Background(), b, Step{N: 1})
+
+	bustest.Timeout = 0
+	defer func() { bustest.Timeout = defaultTimeout }()
+
+	ft := &fakeT{TB: t}
+	func() {
+		defer func() { recover() }()
+		bustest.AssertOrder(ft, rec, Step{N: 1}, Step{N: 2})
+	}()
+	if !ft.failed {
+		t.Fatal("Expected AssertOrder to fail")
+	}
+}
+
+var defaultTimeout = bustest.Timeout
+
+// fakeT records failures instead of aborting the surrounding test.
+type fakeT struct {
+	testing.TB
+	failed bool
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.failed = true
+	panic("fatal")
+}
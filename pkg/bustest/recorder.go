@@ -0,0 +1,40 @@
+package bustest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+// Recorder captures every event emitted on a bus, in delivery order.
+type Recorder struct {
+	mu     sync.Mutex
+	events []any
+}
+
+// Record subscribes a new Recorder to all events emitted on b.
+func Record(b *bus.Bus) *Recorder {
+	r := &Recorder{}
+	bus.SubscribeWildcard(b, func(ctx context.Context, event any) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.events = append(r.events, event)
+		return nil
+	})
+	return r
+}
+
+// Events returns a copy of the recorded events.
+func (r *Recorder) Events() []any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]any(nil), r.events...)
+}
+
+// Reset discards the recorded events.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = nil
+}
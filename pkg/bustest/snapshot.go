@@ -0,0 +1,60 @@
+package bustest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+var update = flag.Bool("bustest.update", false, "rewrite bustest golden files")
+
+// FormatTopology renders the topology of b as stable text.
+func FormatTopology(b *bus.Bus) string {
+	var sb strings.Builder
+	for _, tt := range bus.Topology(b) {
+		sb.WriteString(tt.Type.String())
+		if tt.Producer != "" {
+			fmt.Fprintf(&sb, " producer=%q", tt.Producer)
+		}
+		if tt.Deprecated != "" {
+			fmt.Fprintf(&sb, " deprecated=%q", tt.Deprecated)
+		}
+		sb.WriteByte('\n')
+		for _, h := range tt.Handlers {
+			fmt.Fprintf(&sb, "  %d %s\n", h.Priority, h.Name)
+		}
+	}
+	return sb.String()
+}
+
+// SnapshotTopology compares the topology of b with the golden file
+// testdata/<test name>.topology.golden. Run the tests with -bustest.update
+// to write the current topology instead.
+func SnapshotTopology(t testing.TB, b *bus.Bus) {
+	t.Helper()
+	got := FormatTopology(b)
+	path := filepath.Join("testdata", strings.ReplaceAll(t.Name(), "/", "_")+".topology.golden")
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("bustest: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("bustest: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("bustest: %v (run with -bustest.update to create it)", err)
+	}
+	if string(want) != got {
+		t.Fatalf("bustest: topology does not match %s\nwant:\n%s\ngot:\n%s", path, want, got)
+	}
+}
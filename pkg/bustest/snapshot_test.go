@@ -0,0 +1,26 @@
+package bustest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+	"github.com/mirkobrombin/go-signal/v2/pkg/bustest"
+)
+
+type Shipped struct{}
+
+func onStep(ctx context.Context, e Step) error       { return nil }
+func auditStep(ctx context.Context, e Step) error    { return nil }
+func onShipped(ctx context.Context, e Shipped) error { return nil }
+
+func TestSnapshotTopology(t *testing.T) {
+	b := bus.New()
+	bus.DeclareProducer[Step](b, "workflow")
+	bus.Deprecate[Shipped](b, "use Delivered")
+	bus.Subscribe(b, onStep)
+	bus.Subscribe(b, auditStep, bus.PriorityHigh)
+	bus.Subscribe(b, onShipped, bus.PriorityLow)
+
+	bustest.SnapshotTopology(t, b)
+}
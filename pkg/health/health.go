@@ -0,0 +1,103 @@
+// Package health aggregates the health of components from typed status
+// events emitted on a bus.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+// State is the health of a component. States are ordered by severity.
+type State string
+
+const (
+	Up       State = "up"
+	Degraded State = "degraded"
+	Down     State = "down"
+)
+
+func (s State) severity() int {
+	switch s {
+	case Up:
+		return 0
+	case Degraded:
+		return 1
+	}
+	return 2
+}
+
+// Status is the event components emit to report their health.
+type Status struct {
+	Component string    `json:"component"`
+	State     State     `json:"state"`
+	Message   string    `json:"message,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// Report is the aggregated view: State is the most severe state among
+// the components.
+type Report struct {
+	State      State             `json:"state"`
+	Components map[string]Status `json:"components"`
+}
+
+// Aggregator keeps the latest Status of every component.
+type Aggregator struct {
+	sub *bus.Subscription
+
+	mu         sync.RWMutex
+	components map[string]Status
+}
+
+// New returns an aggregator listening for Status events on b.
+func New(b *bus.Bus) *Aggregator {
+	a := &Aggregator{components: map[string]Status{}}
+	a.sub = bus.Subscribe(b, a.record, bus.WithName("health.Aggregator"))
+	return a
+}
+
+func (a *Aggregator) record(_ context.Context, s Status) error {
+	if s.Time.IsZero() {
+		s.Time = time.Now()
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.components[s.Component] = s
+	return nil
+}
+
+// Report returns the aggregated health. With no components reported the
+// state is Up.
+func (a *Aggregator) Report() Report {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	r := Report{State: Up, Components: make(map[string]Status, len(a.components))}
+	for name, s := range a.components {
+		r.Components[name] = s
+		if s.State.severity() > r.State.severity() {
+			r.State = s.State
+		}
+	}
+	return r
+}
+
+// ServeHTTP writes the report as JSON, with status 503 when the aggregated
+// state is Down.
+func (a *Aggregator) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	r := a.Report()
+	w.Header().Set("Content-Type", "application/json")
+	if r.State == Down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(r)
+}
+
+// Close stops listening for Status events.
+func (a *Aggregator) Close() {
+	a.sub.Cancel()
+}
@@ -0,0 +1,48 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+	"github.com/mirkobrombin/go-signal/v2/pkg/health"
+)
+
+func TestAggregator(t *testing.T) {
+	b := bus.New()
+	agg := health.New(b)
+	ctx := context.Background()
+
+	if got := agg.Report().State; got != health.Up {
+		t.Fatalf("Expected up with no components, got %s", got)
+	}
+
+	_ = bus.Emit(ctx, b, health.Status{Component: "db", State: health.Up})
+	_ = bus.Emit(ctx, b, health.Status{Component: "cache", State: health.Degraded, Message: "slow"})
+	if r := agg.Report(); r.State != health.Degraded || len(r.Components) != 2 {
+		t.Fatalf("Unexpected report: %+v", r)
+	}
+
+	_ = bus.Emit(ctx, b, health.Status{Component: "db", State: health.Down})
+	rec := httptest.NewRecorder()
+	agg.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503, got %d", rec.Code)
+	}
+	var r health.Report
+	if err := json.NewDecoder(rec.Body).Decode(&r); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if r.State != health.Down || r.Components["cache"].Message != "slow" {
+		t.Fatalf("Unexpected report: %+v", r)
+	}
+
+	agg.Close()
+	_ = bus.Emit(ctx, b, health.Status{Component: "db", State: health.Up})
+	if agg.Report().State != health.Down {
+		t.Fatal("Expected closed aggregator to ignore updates")
+	}
+}
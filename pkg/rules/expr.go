@@ -0,0 +1,286 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expr is a compiled filter expression. The syntax is a list of field
+// comparisons joined by && and ||, with && binding tighter:
+//
+//	Amount > 100 && Currency == "EUR" || Customer.VIP == true
+//
+// Fields are exported struct fields or string map keys, separated by dots.
+// Comparisons are ==, !=, <, <=, > and >= against string, number or bool
+// literals. A comparison on a missing field never matches.
+type Expr struct {
+	src string
+	any [][]comparison
+}
+
+type comparison struct {
+	path  []string
+	op    string
+	value any
+}
+
+// Compile parses src into an Expr.
+func Compile(src string) (*Expr, error) {
+	toks, err := tokenize(src)
+	if err != nil {
+		return nil, fmt.Errorf("rules: %q: %w", src, err)
+	}
+	e := &Expr{src: src}
+	var all []comparison
+	for i := 0; i < len(toks); {
+		if len(toks)-i < 3 {
+			return nil, fmt.Errorf("rules: %q: incomplete comparison", src)
+		}
+		field, op, lit := toks[i], toks[i+1], toks[i+2]
+		if field.kind != tokIdent || op.kind != tokOp || lit.kind != tokLiteral {
+			return nil, fmt.Errorf("rules: %q: expected <field> <op> <value> at %q", src, field.text)
+		}
+		all = append(all, comparison{path: strings.Split(field.text, "."), op: op.text, value: lit.value})
+		i += 3
+		if i == len(toks) {
+			break
+		}
+		switch toks[i].text {
+		case "&&":
+		case "||":
+			e.any = append(e.any, all)
+			all = nil
+		default:
+			return nil, fmt.Errorf("rules: %q: expected && or || at %q", src, toks[i].text)
+		}
+		i++
+		if i == len(toks) {
+			return nil, fmt.Errorf("rules: %q: dangling operator", src)
+		}
+	}
+	if len(all) == 0 {
+		return nil, fmt.Errorf("rules: empty expression")
+	}
+	e.any = append(e.any, all)
+	return e, nil
+}
+
+// MustCompile is like Compile but panics on error.
+func MustCompile(src string) *Expr {
+	e, err := Compile(src)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Match reports whether event satisfies the expression.
+func (e *Expr) Match(event any) bool {
+	for _, all := range e.any {
+		ok := true
+		for _, c := range all {
+			if !c.match(event) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c comparison) match(event any) bool {
+	v, ok := lookup(reflect.ValueOf(event), c.path)
+	if !ok {
+		return false
+	}
+	switch want := c.value.(type) {
+	case string:
+		if v.Kind() != reflect.String {
+			return false
+		}
+		return compare(strings.Compare(v.String(), want), c.op)
+	case float64:
+		got, ok := number(v)
+		if !ok {
+			return false
+		}
+		switch {
+		case got < want:
+			return compare(-1, c.op)
+		case got > want:
+			return compare(1, c.op)
+		}
+		return compare(0, c.op)
+	case bool:
+		if v.Kind() != reflect.Bool {
+			return false
+		}
+		switch c.op {
+		case "==":
+			return v.Bool() == want
+		case "!=":
+			return v.Bool() != want
+		}
+	}
+	return false
+}
+
+func compare(cmp int, op string) bool {
+	switch op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+func lookup(v reflect.Value, path []string) (reflect.Value, bool) {
+	for _, name := range path {
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			f, ok := v.Type().FieldByName(name)
+			if !ok || !f.IsExported() {
+				return reflect.Value{}, false
+			}
+			v = v.FieldByIndex(f.Index)
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, false
+			}
+			v = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			if !v.IsValid() {
+				return reflect.Value{}, false
+			}
+		default:
+			return reflect.Value{}, false
+		}
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+func number(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+type tokKind int
+
+const (
+	tokIdent tokKind = iota
+	tokOp
+	tokLiteral
+	tokLogic
+)
+
+type token struct {
+	kind  tokKind
+	text  string
+	value any
+}
+
+func tokenize(src string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '"':
+			j := i + 1
+			for j < len(src) && src[j] != '"' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			s, err := strconv.Unquote(src[i : j+1])
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: tokLiteral, text: src[i : j+1], value: s})
+			i = j + 1
+		case strings.HasPrefix(src[i:], "&&"), strings.HasPrefix(src[i:], "||"):
+			toks = append(toks, token{kind: tokLogic, text: src[i : i+2]})
+			i += 2
+		case strings.ContainsRune("=!<>", c):
+			j := i + 1
+			if j < len(src) && src[j] == '=' {
+				j++
+			}
+			op := src[i:j]
+			if op == "=" || op == "!" {
+				return nil, fmt.Errorf("invalid operator %q", op)
+			}
+			toks = append(toks, token{kind: tokOp, text: op})
+			i = j
+		case c == '-' || c == '.' || unicode.IsDigit(c):
+			j := i + 1
+			for j < len(src) && (src[j] == '.' || unicode.IsDigit(rune(src[j]))) {
+				j++
+			}
+			f, err := strconv.ParseFloat(src[i:j], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q", src[i:j])
+			}
+			toks = append(toks, token{kind: tokLiteral, text: src[i:j], value: f})
+			i = j
+		case c == '_' || unicode.IsLetter(c):
+			j := i + 1
+			for j < len(src) && (src[j] == '_' || src[j] == '.' || unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j]))) {
+				j++
+			}
+			word := src[i:j]
+			switch word {
+			case "true", "false":
+				toks = append(toks, token{kind: tokLiteral, text: word, value: word == "true"})
+			default:
+				toks = append(toks, token{kind: tokIdent, text: word})
+			}
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected %q", c)
+		}
+	}
+	return toks, nil
+}
@@ -0,0 +1,153 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync/atomic"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+)
+
+// Action tells the engine what to do with an event matching a rule.
+type Action string
+
+const (
+	// Pass delivers the event and stops evaluating further rules.
+	Pass Action = "pass"
+	// Drop discards the event before any handler runs.
+	Drop Action = "drop"
+	// Route delivers the event as usual and also emits it on the topic of
+	// the rule.
+	Route Action = "route"
+	// Retopic emits the event on the topic of the rule instead of
+	// delivering it where it was emitted.
+	Retopic Action = "retopic"
+)
+
+// Rule is a declarative routing rule. Type restricts the rule to events
+// whose Go type name (as printed by reflect, e.g. "orders.Created") matches;
+// When is an expression in the syntax accepted by Compile. An empty Type or
+// When matches every event. Topic is the destination of Route and Retopic.
+type Rule struct {
+	Name   string `json:"name"`
+	Type   string `json:"type,omitempty"`
+	When   string `json:"when,omitempty"`
+	Action Action `json:"action"`
+	Topic  string `json:"topic,omitempty"`
+}
+
+type compiled struct {
+	Rule
+	id   uint64
+	expr *Expr
+}
+
+type appliedKey struct{}
+
+var ruleIDs atomic.Uint64
+
+// apply marks ctx as having gone through the rule id, reporting false if
+// it already had.
+func apply(ctx context.Context, id uint64) (context.Context, bool) {
+	applied, _ := ctx.Value(appliedKey{}).([]uint64)
+	if slices.Contains(applied, id) {
+		return ctx, false
+	}
+	return context.WithValue(ctx, appliedKey{}, append(applied[:len(applied):len(applied)], id)), true
+}
+
+// Engine evaluates rules in order against each emitted event; the first
+// matching rule decides its fate. Events matching no rule are delivered.
+// Rules can be replaced at runtime with Set.
+type Engine struct {
+	rules atomic.Pointer[[]compiled]
+}
+
+// New returns an engine evaluating rules.
+func New(rules ...Rule) (*Engine, error) {
+	e := &Engine{}
+	if err := e.Set(rules...); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Set replaces the rules of the engine. On error the current rules are kept.
+func (e *Engine) Set(rules ...Rule) error {
+	out := make([]compiled, 0, len(rules))
+	for _, r := range rules {
+		c := compiled{Rule: r, id: ruleIDs.Add(1)}
+		switch r.Action {
+		case Pass, Drop:
+		case Route, Retopic:
+			if r.Topic == "" {
+				return fmt.Errorf("rules: %s: %s without a topic", r.Name, r.Action)
+			}
+		default:
+			return fmt.Errorf("rules: %s: unknown action %q", r.Name, r.Action)
+		}
+		if r.When != "" {
+			expr, err := Compile(r.When)
+			if err != nil {
+				return fmt.Errorf("rules: %s: %w", r.Name, err)
+			}
+			c.expr = expr
+		}
+		out = append(out, c)
+	}
+	e.rules.Store(&out)
+	return nil
+}
+
+// Evaluate returns the rule matching event, if any.
+func (e *Engine) Evaluate(event any) (Rule, bool) {
+	r, ok := e.match(event)
+	return r.Rule, ok
+}
+
+func (e *Engine) match(event any) (compiled, bool) {
+	typ := reflect.TypeOf(event)
+	for _, r := range *e.rules.Load() {
+		if r.Type != "" && (typ == nil || typ.String() != r.Type) {
+			continue
+		}
+		if r.expr != nil && !r.expr.Match(event) {
+			continue
+		}
+		return r, true
+	}
+	return compiled{}, false
+}
+
+// Middleware returns a middleware applying the engine to the events of b,
+// to be installed with b.Use. Route and Retopic emit on b with
+// EmitTopicAny; like Convert, a rule is applied at most once along a chain
+// of dispatches, so a Retopic rule matching the events it emits does not
+// loop.
+func (e *Engine) Middleware(b *bus.Bus) bus.Middleware {
+	return func(ctx context.Context, event any, next func(ctx context.Context, event any) error) error {
+		r, ok := e.match(event)
+		if !ok {
+			return next(ctx, event)
+		}
+		switch r.Action {
+		case Drop:
+			return nil
+		case Route, Retopic:
+			routed, ok := apply(ctx, r.id)
+			if !ok {
+				return next(ctx, event)
+			}
+			if r.Action == Retopic {
+				return bus.EmitTopicAny(routed, b, r.Topic, event)
+			}
+			if err := next(ctx, event); err != nil {
+				return err
+			}
+			return bus.EmitTopicAny(routed, b, r.Topic, event)
+		}
+		return next(ctx, event)
+	}
+}
@@ -0,0 +1,153 @@
+package rules_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+	"github.com/mirkobrombin/go-signal/v2/pkg/rules"
+)
+
+type Customer struct {
+	VIP bool
+}
+
+type Order struct {
+	Amount   float64
+	Currency string
+	Customer *Customer
+	Tags     map[string]string
+}
+
+func TestExpr_Match(t *testing.T) {
+	order := Order{
+		Amount:   150,
+		Currency: "EUR",
+		Customer: &Customer{VIP: true},
+		Tags:     map[string]string{"channel": "web"},
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`Amount > 100`, true},
+		{`Amount <= 100`, false},
+		{`Currency == "EUR" && Amount >= 150`, true},
+		{`Currency == "USD" || Customer.VIP == true`, true},
+		{`Currency != "EUR" || Amount < 0`, false},
+		{`Tags.channel == "web"`, true},
+		{`Missing == 1`, false},
+		{`Amount == -1.5`, false},
+	}
+	for _, tt := range tests {
+		if got := rules.MustCompile(tt.expr).Match(&order); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestExpr_CompileErrors(t *testing.T) {
+	for _, src := range []string{``, `Amount >`, `Amount = 1`, `Amount > 1 &&`, `"x" == Amount`, `Name == "open`} {
+		if _, err := rules.Compile(src); err == nil {
+			t.Errorf("%q: expected error", src)
+		}
+	}
+}
+
+func TestEngine_Middleware(t *testing.T) {
+	engine, err := rules.New(
+		rules.Rule{Name: "keep-vip", When: `Customer.VIP == true`, Action: rules.Pass},
+		rules.Rule{Name: "drop-small", Type: "rules_test.Order", When: `Amount < 10`, Action: rules.Drop},
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	b := bus.New()
+	b.Use(engine.Middleware(b))
+	var got []float64
+	bus.Subscribe(b, func(ctx context.Context, o Order) error {
+		got = append(got, o.Amount)
+		return nil
+	})
+
+	ctx := context.Background()
+	_ = bus.Emit(ctx, b, Order{Amount: 5, Customer: &Customer{}})
+	_ = bus.Emit(ctx, b, Order{Amount: 6, Customer: &Customer{VIP: true}})
+	_ = bus.Emit(ctx, b, Order{Amount: 50, Customer: &Customer{}})
+
+	if len(got) != 2 || got[0] != 6 || got[1] != 50 {
+		t.Fatalf("Unexpected deliveries: %v", got)
+	}
+
+	if err := engine.Set(rules.Rule{Name: "drop-all", Action: rules.Drop}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	_ = bus.Emit(ctx, b, Order{Amount: 100})
+	if len(got) != 2 {
+		t.Fatalf("Expected event dropped after reconfiguration, got %v", got)
+	}
+
+	if err := engine.Set(rules.Rule{Name: "bad", Action: "reroute"}); err == nil {
+		t.Fatal("Expected error for unknown action")
+	}
+}
+
+func TestEngine_Retopic(t *testing.T) {
+	engine, err := rules.New(
+		rules.Rule{Name: "vip", When: `Customer.VIP == true`, Action: rules.Retopic, Topic: "orders.vip"},
+		rules.Rule{Name: "audit", When: `Amount > 100`, Action: rules.Route, Topic: "orders.audit"},
+	)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	b := bus.New()
+	b.Use(engine.Middleware(b))
+	var plain, vip, audit []float64
+	bus.Subscribe(b, func(ctx context.Context, o Order) error {
+		plain = append(plain, o.Amount)
+		return nil
+	})
+	bus.SubscribeTopic(b, "orders.vip", func(ctx context.Context, o Order) error {
+		vip = append(vip, o.Amount)
+		return nil
+	})
+	bus.SubscribeTopic(b, "orders.audit", func(ctx context.Context, o Order) error {
+		audit = append(audit, o.Amount)
+		return nil
+	})
+
+	ctx := context.Background()
+	_ = bus.Emit(ctx, b, Order{Amount: 5, Customer: &Customer{VIP: true}})
+	_ = bus.Emit(ctx, b, Order{Amount: 500, Customer: &Customer{}})
+	_ = bus.Emit(ctx, b, Order{Amount: 50, Customer: &Customer{}})
+
+	if !slices.Equal(plain, []float64{500, 50}) || !slices.Equal(vip, []float64{5}) || !slices.Equal(audit, []float64{500}) {
+		t.Fatalf("Unexpected deliveries: plain %v, vip %v, audit %v", plain, vip, audit)
+	}
+
+	if err := engine.Set(rules.Rule{Name: "no-topic", Action: rules.Route}); err == nil {
+		t.Fatal("Expected error for Route without a topic")
+	}
+}
+
+func TestExpr_SubscriptionFilter(t *testing.T) {
+	b := bus.New()
+	var got []float64
+	bus.Subscribe(b, func(ctx context.Context, o Order) error {
+		got = append(got, o.Amount)
+		return nil
+	}, bus.WithMatcher(rules.MustCompile(`Currency == "EUR" && Amount > 100`)))
+
+	ctx := context.Background()
+	_ = bus.Emit(ctx, b, Order{Amount: 200, Currency: "USD"})
+	_ = bus.Emit(ctx, b, Order{Amount: 50, Currency: "EUR"})
+	_ = bus.Emit(ctx, b, Order{Amount: 150, Currency: "EUR"})
+
+	if len(got) != 1 || got[0] != 150 {
+		t.Fatalf("Unexpected deliveries: %v", got)
+	}
+}
@@ -0,0 +1,40 @@
+package signal
+
+import "context"
+
+// Notifier delivers values from one producer to one consumer without the
+// machinery of a Bus. A value not yet awaited is replaced by the next one,
+// so Notify never blocks and Await always sees the latest value.
+type Notifier[T any] struct {
+	ch chan T
+}
+
+func NewNotifier[T any]() *Notifier[T] {
+	return &Notifier[T]{ch: make(chan T, 1)}
+}
+
+// Notify publishes v, replacing any value not yet awaited.
+func (n *Notifier[T]) Notify(v T) {
+	for {
+		select {
+		case n.ch <- v:
+			return
+		default:
+		}
+		select {
+		case <-n.ch:
+		default:
+		}
+	}
+}
+
+// Await waits for the next value or for ctx to be done.
+func (n *Notifier[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case v := <-n.ch:
+		return v, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
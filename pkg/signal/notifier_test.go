@@ -0,0 +1,35 @@
+package signal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/signal"
+)
+
+func TestNotifier(t *testing.T) {
+	n := signal.NewNotifier[int]()
+	n.Notify(1)
+	n.Notify(2)
+
+	v, err := n.Await(context.Background())
+	if err != nil || v != 2 {
+		t.Fatalf("Expected latest value 2, got %d, %v", v, err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		n.Notify(3)
+	}()
+	if v, _ := n.Await(context.Background()); v != 3 {
+		t.Fatalf("Expected 3, got %d", v)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := n.Await(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected DeadlineExceeded, got %v", err)
+	}
+}
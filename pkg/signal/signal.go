@@ -10,6 +10,9 @@ type Bus = bus.Bus
 type Handler[T any] = bus.Handler[T]
 type Priority = bus.Priority
 type DispatchStrategy = bus.DispatchStrategy
+type Strategy = bus.Strategy
+type SubscribeOption = bus.SubscribeOption
+type Subscription = bus.Subscription
 
 const (
 	PriorityHigh   = bus.PriorityHigh
@@ -24,10 +27,14 @@ var (
 	New          = bus.New
 	Default      = bus.Default
 	WithStrategy = bus.WithStrategy
+
+	Parallel               = bus.Parallel
+	FirstSuccess           = bus.FirstSuccess
+	CancelRemainingOnError = bus.CancelRemainingOnError
 )
 
-func Subscribe[T any](b *Bus, fn Handler[T], priority ...Priority) {
-	bus.Subscribe(b, fn, priority...)
+func Subscribe[T any](b *Bus, fn Handler[T], opts ...SubscribeOption) *Subscription {
+	return bus.Subscribe(b, fn, opts...)
 }
 
 func Emit[T any](ctx context.Context, b *Bus, event T) error {
@@ -41,3 +48,7 @@ func EmitAsync[T any](ctx context.Context, b *Bus, event T) {
 func SubscribeWildcard(b *Bus, fn func(ctx context.Context, event any) error) {
 	bus.SubscribeWildcard(b, fn)
 }
+
+func SubscribeAll(b *Bus, fn func(ctx context.Context, event any) error, opts ...SubscribeOption) *Subscription {
+	return bus.SubscribeAll(b, fn, opts...)
+}
@@ -0,0 +1,24 @@
+package signal_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/bus"
+	"github.com/mirkobrombin/go-signal/v2/pkg/signal"
+)
+
+type Ping struct{}
+
+func TestEmit_ProducerAttribution(t *testing.T) {
+	b := signal.New(bus.WithProducerQuota(bus.AnyProducer, 100, time.Minute))
+	if err := signal.Emit(context.Background(), b, Ping{}); err != nil {
+		t.Fatalf("Emit failed: %v", err)
+	}
+
+	stats := b.ProducerStats()
+	if stats["github.com/mirkobrombin/go-signal/v2/pkg/signal_test"] != 1 {
+		t.Fatalf("Expected the emission charged to the caller, got %v", stats)
+	}
+}
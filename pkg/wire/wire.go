@@ -0,0 +1,73 @@
+// Package wire defines the envelope events travel in when they leave the
+// process. It has no dependency on the bus, so lightweight producers and
+// consumers can exchange events without the dispatch machinery.
+package wire
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Codec encodes event payloads.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec encodes payloads with encoding/json.
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (JSONCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// Envelope is an encoded event with its metadata. Its JSON form, one
+// envelope per line, is also what bus.ImportNDJSON reads.
+type Envelope struct {
+	ID      string            `json:"id,omitempty"`
+	Type    string            `json:"type"`
+	Time    time.Time         `json:"time,omitzero"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Data    json.RawMessage   `json:"data"`
+}
+
+// Encode wraps event in an envelope of the given type name.
+func Encode(typeName string, event any, codec Codec) (Envelope, error) {
+	data, err := codec.Marshal(event)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("wire: encoding %s: %w", typeName, err)
+	}
+	return Envelope{ID: NewID(), Type: typeName, Time: time.Now().UTC(), Data: data}, nil
+}
+
+// Decode unwraps the payload of env into a T.
+func Decode[T any](env Envelope, codec Codec) (T, error) {
+	var v T
+	if err := codec.Unmarshal(env.Data, &v); err != nil {
+		return v, fmt.Errorf("wire: decoding %s: %w", env.Type, err)
+	}
+	return v, nil
+}
+
+// Marshal returns the JSON form of env.
+func Marshal(env Envelope) ([]byte, error) {
+	return json.Marshal(env)
+}
+
+// Unmarshal parses the JSON form of an envelope.
+func Unmarshal(data []byte) (Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return Envelope{}, fmt.Errorf("wire: %w", err)
+	}
+	return env, nil
+}
+
+// NewID returns a random envelope identifier.
+func NewID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
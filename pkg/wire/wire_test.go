@@ -0,0 +1,47 @@
+package wire_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mirkobrombin/go-signal/v2/pkg/wire"
+)
+
+type UserCreated struct {
+	ID   int
+	Name string
+}
+
+func TestRoundTrip(t *testing.T) {
+	env, err := wire.Encode("user.created", UserCreated{ID: 1, Name: "Ada"}, wire.JSONCodec{})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	env.Headers = map[string]string{"tenant": "acme"}
+
+	data, err := wire.Marshal(env)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	got, err := wire.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got.ID != env.ID || got.Type != "user.created" || got.Headers["tenant"] != "acme" {
+		t.Fatalf("Unexpected envelope: %+v", got)
+	}
+
+	user, err := wire.Decode[UserCreated](got, wire.JSONCodec{})
+	if err != nil || user.Name != "Ada" {
+		t.Fatalf("Unexpected payload: %+v, %v", user, err)
+	}
+
+	if _, err := wire.Decode[UserCreated](wire.Envelope{Type: "x", Data: []byte(`"nope"`)}, wire.JSONCodec{}); err == nil {
+		t.Fatal("Expected decode error")
+	}
+
+	bare, err := wire.Marshal(wire.Envelope{Type: "x", Data: []byte(`{}`)})
+	if err != nil || strings.Contains(string(bare), `"time"`) {
+		t.Fatalf("Expected a zero time to be omitted, got %s, %v", bare, err)
+	}
+}